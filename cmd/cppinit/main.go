@@ -1,221 +1,785 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"io"
+	"log"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/nikitalobanov12/cppinit/internal/scaffold"
+	"github.com/nikitalobanov12/cppinit/internal/server"
+	"github.com/nikitalobanov12/cppinit/internal/templates"
+	"github.com/nikitalobanov12/cppinit/pkg/cppinit"
 )
 
 var version = "dev"
 
 func main() {
-	if err := run(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
-	}
+	os.Exit(finish(run()))
 }
 
 func run() error {
-	// Parse flags
-	showVersion := flag.Bool("version", false, "Show version")
-	showHelp := flag.Bool("help", false, "Show help")
-
-	// Quick create flags (non-interactive)
-	name := flag.String("name", "", "Project name (enables non-interactive mode)")
-	description := flag.String("desc", "", "Project description")
-	author := flag.String("author", "", "Author name")
-	language := flag.String("lang", "c++", "Language (c, c++)")
-	std := flag.String("std", "", "Standard (C: 89, 99, 11, 17, 23 | C++: 11, 14, 17, 20, 23)")
-	projectType := flag.String("type", "executable", "Project type (executable, static, header-only)")
-	testFw := flag.String("tests", "none", "Test framework (none, googletest, catch2, doctest for C++; none, unity for C)")
-	pkgMgr := flag.String("pkg", "none", "Package manager (none, vcpkg, conan, cpm)")
-	license := flag.String("license", "mit", "License (none, mit, apache2, gpl3, bsd3)")
-
-	// Feature flags
-	clangFormat := flag.Bool("clang-format", true, "Include clang-format configuration")
-	clangTidy := flag.Bool("clang-tidy", true, "Include clang-tidy configuration")
-	sanitizers := flag.Bool("sanitizers", false, "Include sanitizer support")
-	coverage := flag.Bool("coverage", false, "Include code coverage support")
-	doxygen := flag.Bool("doxygen", false, "Include Doxygen documentation")
-	docker := flag.Bool("docker", false, "Include Docker/devcontainer support")
-	precommit := flag.Bool("precommit", false, "Include pre-commit hooks")
-	ci := flag.Bool("ci", false, "Include GitHub Actions CI")
-	vscode := flag.Bool("vscode", false, "Include VSCode configuration")
-	benchmark := flag.Bool("benchmark", false, "Include Google Benchmark")
-
-	// Preset flags
-	full := flag.Bool("full", false, "Include all features (same as --all)")
-	minimal := flag.Bool("minimal", false, "Minimal project (no extra tools)")
-
-	flag.Parse()
-
-	if *showVersion {
+	args := os.Args[1:]
+	if len(args) == 0 {
+		return runNew(nil)
+	}
+
+	name := args[0]
+	switch {
+	case name == "help" || name == "-h" || name == "-help" || name == "--help":
+		return runHelp(args[1:])
+	case name == "-version" || name == "--version":
+		return runVersion(nil)
+	case strings.HasPrefix(name, "-"):
+		// Flags without a command are the pre-subcommand interface
+		warn("\"cppinit -name <name> [flags]\" is deprecated; use \"cppinit new <name> [flags]\"")
+		return runNew(args)
+	}
+
+	for _, c := range commands() {
+		if c.name == name {
+			return c.run(args[1:])
+		}
+	}
+	return fmt.Errorf("unknown command %q; run \"cppinit help\" for a list of commands", name)
+}
+
+// command is one cppinit subcommand
+type command struct {
+	name    string
+	args    string
+	summary string
+	run     func(args []string) error
+}
+
+// commands lists the subcommands in the order the help shows them
+func commands() []command {
+	return []command{
+		{"new", "[flags] [name]", "Create a project in ./<name>; runs the wizard without a name", runNew},
+		{"init", "[flags] [dir]", "Turn an existing directory into a project", runInit},
+		{"add", "[flags] <feature> [dir]", "Enable a feature in an existing project", runAdd},
+		{"remove", "[flags] <feature> [dir]", "Strip a feature from an existing project", runRemove},
+		{"upgrade", "[flags] [dir]", "Merge the current templates into an existing project", runUpgrade},
+		{"info", "[dir]", "Show how a project was generated and which files changed", runInfo},
+		{"doctor", "[flags] [dir]", "Check that cmake, a compiler and other needed tools are installed", runDoctor},
+		{"list", "[options|features|presets|packs]", "List option values, features, presets and template packs", runList},
+		{"pack", "install|list", "Install and list template packs", runPack},
+		{"config", "get|set|unset|list", "Manage the defaults every new project starts from", runConfig},
+		{"serve", "[flags]", "Serve a web form and JSON API that return projects as zip archives", runServe},
+		{"version", "", "Print the version", runVersion},
+		{"help", "[command]", "Show help for cppinit or one command", runHelp},
+	}
+}
+
+// runHelp prints the command overview, or the usage of one command
+func runHelp(args []string) error {
+	if len(args) > 0 {
+		if args[0] == "help" {
+			return runHelp(nil)
+		}
+		for _, c := range commands() {
+			if c.name == args[0] {
+				return c.run([]string{"-h"})
+			}
+		}
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+
+	fmt.Println("cppinit - Create C/C++ projects with modern CMake")
+	fmt.Println()
+	fmt.Println("Usage:")
+	fmt.Println("  cppinit                    Run the interactive project wizard")
+	fmt.Println("  cppinit <command> [args]")
+	fmt.Println()
+	fmt.Println("Commands:")
+	for _, c := range commands() {
+		fmt.Printf("  %-8s %-32s %s\n", c.name, c.args, c.summary)
+	}
+	fmt.Println()
+	fmt.Println(`Run "cppinit help <command>" for the flags of a command.`)
+	fmt.Println(helpExamples)
+	return nil
+}
+
+const helpExamples = `
+Examples:
+  # Interactive wizard
+  cppinit
+
+  # Quick C++ executable with defaults
+  cppinit new myapp
+
+  # C library with Unity tests
+  cppinit new myclib -lang c -type static -tests unity
+
+  # Full-featured C++ library
+  cppinit new mylib -type static -std 20 -tests googletest -full
+
+  # Turn the current checkout into a project
+  cppinit init
+
+  # Save a team recipe once, then reuse it for every new project
+  cppinit new mylib -type static -std 20 -tests googletest -save-config cppinit.yaml
+  cppinit new otherlib -config cppinit.yaml
+
+  # Review what a flag combination produces without creating anything
+  cppinit new mylib -type static -full -dry-run
+
+  # Generate straight into an archive, or pipe it elsewhere
+  cppinit new myapp -tests catch2 -o myapp.zip
+  cppinit new myapp -o - | ssh build-host tar xzf -
+
+  # Add a feature to an existing project
+  cppinit add sanitizers`
+
+// runVersion prints the version
+func runVersion(args []string) error {
+	fs := flag.NewFlagSet("version", flag.ExitOnError)
+	addJSONFlag(fs, "version")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: cppinit version [-json]")
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if report == nil {
 		fmt.Printf("cppinit %s\n", version)
+	}
+	return nil
+}
+
+// runList prints the valid option values, the features of add and remove,
+// the presets and the installed template packs
+func runList(args []string) error {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	addJSONFlag(fs, "list")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: cppinit list [-json] [options|features|presets|packs]")
+	}
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 1 {
+		fs.Usage()
+		return fmt.Errorf("expected at most one topic")
+	}
+	topic := ""
+	if len(positional) == 1 {
+		topic = positional[0]
+	}
+
+	switch topic {
+	case "", "options", "features", "presets", "packs":
+	default:
+		return fmt.Errorf("unknown topic %q (valid topics: options, features, presets, packs)", topic)
+	}
+	if topic == "" || topic == "options" {
+		if report != nil {
+			report.Options = scaffold.OptionValues()
+		} else {
+			scaffold.PrintOptionValues()
+		}
+	}
+	if topic == "" || topic == "features" {
+		if report != nil {
+			report.Features = scaffold.FeatureReports()
+		} else {
+			scaffold.PrintFeatures()
+		}
+	}
+	if topic == "" || topic == "presets" {
+		presets, err := scaffold.Presets()
+		if err != nil {
+			return err
+		}
+		if report != nil {
+			report.Presets = presets
+		} else {
+			scaffold.PrintPresets(presets)
+		}
+	}
+	if topic == "" || topic == "packs" {
+		packs, err := scaffold.InstalledPacks()
+		if err != nil {
+			return err
+		}
+		if report != nil {
+			report.Packs = packs
+		} else {
+			scaffold.PrintPacks(packs)
+		}
+	}
+	return nil
+}
+
+// runInfo reports how an existing project was generated and which generated
+// files were modified since
+func runInfo(args []string) error {
+	fs := flag.NewFlagSet("info", flag.ExitOnError)
+	addJSONFlag(fs, "info")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: cppinit info [-json] [project-dir]")
+	}
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+
+	dir := "."
+	if len(positional) > 0 {
+		dir = positional[0]
+	}
+
+	manifest, err := scaffold.ReadManifest(dir)
+	if err != nil {
+		return err
+	}
+	statuses, err := manifest.Status(dir)
+	if err != nil {
+		return err
+	}
+
+	if report != nil {
+		report.GeneratedBy = manifest.Version
+		report.OutputDir = dir
+		report.Config = manifest.Config
+		report.Status = statuses
 		return nil
 	}
+	scaffold.PrintInfo(manifest, statuses)
+	return nil
+}
 
-	if *showHelp {
-		printHelp()
+// runUpgrade merges the current templates into an existing project
+func runUpgrade(args []string) error {
+	fs := flag.NewFlagSet("upgrade", flag.ExitOnError)
+	dryRun := fs.Bool("dry-run", false, "Report what would change without writing anything")
+	reject := fs.Bool("rej", false, "Write conflicting template changes to <file>.rej instead of conflict markers")
+	templatesDir := fs.String("templates", "", "Directory of templates that replace the built-in ones")
+	addJSONFlag(fs, "upgrade")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: cppinit upgrade [-dry-run] [-rej] [-templates dir] [-json] [project-dir]")
+		fs.PrintDefaults()
+	}
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+
+	dir := "."
+	if len(positional) > 0 {
+		dir = positional[0]
+	}
+
+	set, err := templateSet(*templatesDir)
+	if err != nil {
+		return err
+	}
+
+	changes, err := scaffold.Upgrade(dir, scaffold.UpgradeOptions{
+		Version:   version,
+		DryRun:    *dryRun,
+		Reject:    *reject,
+		Templates: set,
+	})
+	if err != nil {
+		return err
+	}
+
+	if report != nil {
+		reportChanges(dir, changes, *dryRun)
+		return nil
+	}
+	scaffold.PrintUpgrade(changes, *dryRun)
+	return nil
+}
+
+// runAdd enables a feature in an existing project
+func runAdd(args []string) error {
+	fs := flag.NewFlagSet("add", flag.ExitOnError)
+	testFw := fs.String("tests", "", "Test framework when adding tests (default googletest for C++, unity for C)")
+	force := fs.Bool("force", false, "Overwrite feature files that already exist")
+	skipExisting := fs.Bool("skip-existing", false, "Keep feature files that already exist")
+	dryRun := fs.Bool("dry-run", false, "Report what would change without writing anything")
+	templatesDir := fs.String("templates", "", "Directory of templates that replace the built-in ones")
+	addJSONFlag(fs, "add")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: cppinit add [flags] <feature> [project-dir]")
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "Features:")
+		for _, f := range scaffold.Features {
+			fmt.Fprintf(fs.Output(), "  %-12s %s\n", f.Name, f.Description)
+		}
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "Flags:")
+		fs.PrintDefaults()
+	}
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 || len(positional) > 2 {
+		fs.Usage()
+		return fmt.Errorf("expected a feature name")
+	}
+	if *force && *skipExisting {
+		return fmt.Errorf("-force and -skip-existing cannot be combined")
+	}
+
+	dir := "."
+	if len(positional) == 2 {
+		dir = positional[1]
+	}
+
+	set, err := templateSet(*templatesDir)
+	if err != nil {
+		return err
+	}
+
+	opts := scaffold.FeatureOptions{
+		Version:       version,
+		TestFramework: *testFw,
+		Conflict:      scaffold.ConflictAbort,
+		DryRun:        *dryRun,
+		Templates:     set,
+	}
+	if *force {
+		opts.Conflict = scaffold.ConflictForce
+	} else if *skipExisting {
+		opts.Conflict = scaffold.ConflictSkip
+	}
+
+	changes, err := scaffold.AddFeature(dir, positional[0], opts)
+	if err != nil {
+		return err
+	}
+
+	if report != nil {
+		reportChanges(dir, changes, *dryRun)
 		return nil
 	}
+	scaffold.PrintFeatureChanges("Added "+positional[0], changes, *dryRun)
+	return nil
+}
+
+// runRemove strips a feature from an existing project
+func runRemove(args []string) error {
+	fs := flag.NewFlagSet("remove", flag.ExitOnError)
+	dryRun := fs.Bool("dry-run", false, "Report what would change without writing anything")
+	templatesDir := fs.String("templates", "", "Directory of templates that replace the built-in ones")
+	addJSONFlag(fs, "remove")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: cppinit remove [flags] <feature> [project-dir]")
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "Features:")
+		for _, f := range scaffold.Features {
+			fmt.Fprintf(fs.Output(), "  %-12s %s\n", f.Name, f.Description)
+		}
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "Flags:")
+		fs.PrintDefaults()
+	}
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 || len(positional) > 2 {
+		fs.Usage()
+		return fmt.Errorf("expected a feature name")
+	}
+
+	dir := "."
+	if len(positional) == 2 {
+		dir = positional[1]
+	}
+
+	set, err := templateSet(*templatesDir)
+	if err != nil {
+		return err
+	}
+
+	changes, err := scaffold.RemoveFeature(dir, positional[0], scaffold.FeatureOptions{
+		Version:   version,
+		DryRun:    *dryRun,
+		Templates: set,
+	})
+	if err != nil {
+		return err
+	}
+
+	if report != nil {
+		reportChanges(dir, changes, *dryRun)
+		return nil
+	}
+	scaffold.PrintFeatureChanges("Removed "+positional[0], changes, *dryRun)
+	return nil
+}
+
+// runDoctor checks that the tools a project needs are installed
+func runDoctor(args []string) error {
+	fs := flag.NewFlagSet("doctor", flag.ExitOnError)
+	configPath := fs.String("config", "", "Check the tools needed by the options in a YAML or JSON config file")
+	lang := fs.String("lang", "", "Language: c++, c")
+	std := fs.String("std", "", "C/C++ standard")
+	pkg := fs.String("pkg", "", "Package manager: none, vcpkg, conan, cpm")
+	addJSONFlag(fs, "doctor")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: cppinit doctor [flags] [project-dir]")
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "Checks the tools needed by the project in project-dir (default: the current")
+		fmt.Fprintln(fs.Output(), "directory if it was generated by cppinit), by -config, or by a default C++17 project.")
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "Flags:")
+		fs.PrintDefaults()
+	}
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 1 {
+		fs.Usage()
+		return fmt.Errorf("expected at most one project directory")
+	}
 
 	var config *scaffold.Config
-	var err error
-
-	// Non-interactive mode if name is provided
-	if *name != "" {
-		// Set default standard based on language
-		standard := *std
-		if standard == "" {
-			if *language == "c" {
-				standard = "11" // C11 default
-			} else {
-				standard = "17" // C++17 default
-			}
+	switch {
+	case len(positional) == 1:
+		manifest, err := scaffold.ReadManifest(positional[0])
+		if err != nil {
+			return err
+		}
+		config = manifest.Config
+	case *configPath != "":
+		if config, err = scaffold.UserDefaults(); err != nil {
+			return err
+		}
+		if err := scaffold.ApplyConfigFile(config, *configPath); err != nil {
+			return err
 		}
+	default:
+		if manifest, err := scaffold.ReadManifest("."); err == nil {
+			config = manifest.Config
+		} else if config, err = scaffold.UserDefaults(); err != nil {
+			return err
+		}
+	}
 
-		// Set default description based on language
-		desc := *description
-		if desc == "" {
-			if *language == "c" {
-				desc = "A modern C project"
-			} else {
-				desc = "A modern C++ project"
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "lang":
+			config.Language = *lang
+			if *std == "" {
+				config.Standard = ""
 			}
+		case "std":
+			config.Standard = *std
+		case "pkg":
+			config.PackageManager = *pkg
 		}
-
-		config = &scaffold.Config{
-			ProjectName:      *name,
-			Description:      desc,
-			AuthorName:       *author,
-			Language:         *language,
-			Standard:         standard,
-			ProjectType:      *projectType,
-			TestFramework:    *testFw,
-			PackageManager:   *pkgMgr,
-			License:          *license,
-			UseClangFormat:   *clangFormat,
-			UseClangTidy:     *clangTidy,
-			UseSanitizers:    *sanitizers,
-			UseCoverage:      *coverage,
-			UseDoxygen:       *doxygen,
-			UseDocker:        *docker,
-			UsePreCommit:     *precommit,
-			IncludeCI:        *ci,
-			IncludeVSCode:    *vscode,
-			IncludeBenchmark: *benchmark,
-			OutputDir:        *name,
-		}
-
-		// Apply presets
-		if *full {
-			config.UseClangFormat = true
-			config.UseClangTidy = true
-			config.UseSanitizers = true
-			config.UseCoverage = true
-			config.UseDoxygen = true
-			config.UseDocker = true
-			config.UsePreCommit = true
-			config.IncludeCI = true
-			config.IncludeVSCode = true
-			if config.TestFramework == "none" {
-				config.TestFramework = "googletest"
+	})
+	config.ApplyLanguageDefaults()
+
+	results := scaffold.RunDoctor(config)
+	failed := 0
+	if report != nil {
+		report.Config = config
+		report.Checks = results
+		for _, r := range results {
+			if r.Status == scaffold.CheckFail {
+				failed++
 			}
 		}
+	} else {
+		failed = scaffold.PrintDoctor(config, results)
+	}
+	if failed > 0 {
+		return &scaffold.MissingToolsError{Count: failed}
+	}
+	return nil
+}
+
+// runPack installs and lists template packs
+func runPack(args []string) error {
+	fs := flag.NewFlagSet("pack", flag.ExitOnError)
+	fs.Usage = func() {
+		w := fs.Output()
+		fmt.Fprintln(w, "Usage: cppinit pack install [flags] <dir|archive.tar.gz|url>")
+		fmt.Fprintln(w, "       cppinit pack list [-json]")
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `Run "cppinit pack install -h" for the flags of install.`)
+	}
+	// Stops at the command, whose flags follow it
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	args = fs.Args()
+	if len(args) == 0 {
+		fs.Usage()
+		return fmt.Errorf("expected install or list")
+	}
+
+	switch args[0] {
+	case "help":
+		fs.Usage()
+		return nil
 
-		if *minimal {
-			config.UseClangFormat = false
-			config.UseClangTidy = false
-			config.UseSanitizers = false
-			config.UseCoverage = false
-			config.UseDoxygen = false
-			config.UseDocker = false
-			config.UsePreCommit = false
-			config.IncludeCI = false
-			config.IncludeVSCode = false
+	case "install":
+		fs := flag.NewFlagSet("pack install", flag.ExitOnError)
+		checksum := fs.String("sha256", "", "Expected sha256 of the archive (required for URLs)")
+		force := fs.Bool("force", false, "Replace an installed pack of the same name")
+		addJSONFlag(fs, "pack install")
+		fs.Usage = func() {
+			fmt.Fprintln(fs.Output(), "Usage: cppinit pack install [flags] <dir|archive.tar.gz|url>")
+			fs.PrintDefaults()
 		}
-	} else {
-		// Interactive mode
-		config, err = scaffold.RunPrompts()
+		positional, err := parseInterspersed(fs, args[1:])
+		if err != nil {
+			return err
+		}
+		if len(positional) != 1 {
+			fs.Usage()
+			return fmt.Errorf("expected one pack source")
+		}
+
+		pack, err := scaffold.InstallPack(positional[0], *checksum, *force)
 		if err != nil {
 			return err
 		}
+		if report != nil {
+			report.Packs = []*scaffold.Pack{pack}
+			return nil
+		}
+		scaffold.PrintPackInstalled(pack)
+		return nil
+
+	case "list":
+		fs := flag.NewFlagSet("pack list", flag.ExitOnError)
+		addJSONFlag(fs, "pack list")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if fs.NArg() > 0 {
+			return fmt.Errorf("usage: cppinit pack list [-json]")
+		}
+		packs, err := scaffold.InstalledPacks()
+		if err != nil {
+			return err
+		}
+		if report != nil {
+			report.Packs = packs
+			return nil
+		}
+		scaffold.PrintPacks(packs)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown pack command %q (valid commands: install, list)", args[0])
 	}
+}
 
-	if err := scaffold.Generate(config); err != nil {
+// runConfig reads and changes the user defaults file
+func runConfig(args []string) error {
+	fs := flag.NewFlagSet("config", flag.ExitOnError)
+	addJSONFlag(fs, "config")
+	fs.Usage = func() {
+		w := fs.Output()
+		fmt.Fprintln(w, "Usage: cppinit config get <key> | set <key> <value> | unset <key> | list [-json]")
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Manages the defaults new projects start from, kept in")
+		if path, err := scaffold.DefaultsPath(); err == nil {
+			fmt.Fprintf(w, "%s. %s<KEY> environment variables override the file and\n", path, scaffold.EnvPrefix)
+		}
+		fmt.Fprintln(w, "flags override both.")
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "Keys: %s\n", strings.Join(scaffold.DefaultKeys(), ", "))
+	}
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
 		return err
 	}
+	if len(positional) == 0 {
+		fs.Usage()
+		return fmt.Errorf("expected get, set, unset or list")
+	}
+
+	action, operands := positional[0], positional[1:]
+	want := map[string]int{"get": 1, "set": 2, "unset": 1, "list": 0}
+	n, ok := want[action]
+	if !ok {
+		return fmt.Errorf("unknown config command %q (valid commands: get, set, unset, list)", action)
+	}
+	if len(operands) != n {
+		fs.Usage()
+		return fmt.Errorf("config %s expects %d argument(s)", action, n)
+	}
+
+	switch action {
+	case "set":
+		if err := scaffold.SetDefault(operands[0], operands[1]); err != nil {
+			return err
+		}
+	case "unset":
+		if err := scaffold.UnsetDefault(operands[0]); err != nil {
+			return err
+		}
+	}
 
-	scaffold.PrintSuccess(config)
+	if action == "list" {
+		defaults, err := scaffold.Defaults()
+		if err != nil {
+			return err
+		}
+		if report != nil {
+			report.Defaults = defaults
+		} else {
+			scaffold.PrintDefaults(defaults)
+		}
+		return nil
+	}
+
+	d, err := scaffold.GetDefault(operands[0])
+	if err != nil {
+		return err
+	}
+	if d.Source == scaffold.SourceEnv && action != "get" {
+		warn("$%s%s overrides the value in the defaults file", scaffold.EnvPrefix, strings.ToUpper(d.Key))
+	}
+	if report != nil {
+		report.Defaults = []scaffold.Default{d}
+	} else if action == "get" {
+		fmt.Println(d.Value)
+	}
 	return nil
 }
 
-func printHelp() {
-	fmt.Println(`cppinit - Create C/C++ projects with modern CMake
-
-Usage:
-  cppinit                    Run interactive project wizard
-  cppinit -name <name>       Create project with specified options (non-interactive)
-
-Project Options:
-  -name string         Project name (required for non-interactive mode)
-  -desc string         Project description
-  -author string       Author name for license
-  -lang string         Language: c, c++ (default "c++")
-  -std string          Standard (C: 89, 99, 11, 17, 23 | C++: 11, 14, 17, 20, 23)
-                       Defaults to C11 for C, C++17 for C++
-  -type string         Project type: executable, static, header-only (default "executable")
-  -license string      License: none, mit, apache2, gpl3, bsd3 (default "mit")
-
-Dependencies:
-  -tests string        Test framework:
-                         C++: none, googletest, catch2, doctest (default "none")
-                         C: none, unity (default "none")
-  -pkg string          Package manager: none, vcpkg, conan, cpm (default "none")
-  -benchmark           Include Google Benchmark for performance testing
-
-Code Quality:
-  -clang-format        Include clang-format config (default true)
-  -clang-tidy          Include clang-tidy config (default true)
-  -sanitizers          Include Address/UB/Thread sanitizers
-  -coverage            Include code coverage support
-
-DevOps & Tooling:
-  -ci                  Include GitHub Actions CI
-  -vscode              Include VSCode configuration
-  -docker              Include Dockerfile and devcontainer
-  -precommit           Include pre-commit hooks
-  -doxygen             Include Doxygen documentation setup
-
-Presets:
-  -full                Enable all features (tests, sanitizers, coverage, CI, etc.)
-  -minimal             Minimal project with no extra tooling
-
-Other:
-  -version             Show version
-  -help                Show this help message
+// runServe runs the web initializr until interrupted
+func runServe(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", "localhost:8080", "Address to listen on")
+	templatesDir := fs.String("templates", "", "Directory of templates that replace the built-in ones")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: cppinit serve [flags]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+
+	set, err := templateSet(*templatesDir)
+	if err != nil {
+		return err
+	}
+	g := cppinit.New()
+	g.TemplateDirs = set.Dirs()
+	g.Version = version
+
+	logger := log.New(os.Stderr, "cppinit: ", log.LstdFlags)
+	srv := server.New(g, logger).HTTPServer(*addr)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	errs := make(chan error, 1)
+	go func() { errs <- srv.ListenAndServe() }()
+	logger.Printf("serving on http://%s", *addr)
+
+	select {
+	case err := <-errs:
+		return fmt.Errorf("failed to serve: %w", err)
+	case <-ctx.Done():
+	}
+	shutdown, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return srv.Shutdown(shutdown)
+}
 
-Examples:
-  # Interactive wizard
-  cppinit
+// packOptions collects repeated -pack-opt key=value flags
+type packOptions map[string]any
 
-  # Quick C++ executable with defaults
-  cppinit -name myapp
+func (p packOptions) String() string {
+	return ""
+}
 
-  # C project
-  cppinit -name myapp -lang c -std 11
+func (p packOptions) Set(value string) error {
+	key, val, ok := strings.Cut(value, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("expected key=value, got %q", value)
+	}
+	p[key] = val
+	return nil
+}
 
-  # Full-featured C++ library
-  cppinit -name mylib -type static -std 20 -tests googletest -full
+// templateSet searches the -templates directory, then the per-user
+// template directory, before the built-in templates
+func templateSet(dir string) (*templates.Set, error) {
+	if dir != "" {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("template directory %s does not exist", dir)
+		}
+	}
+	return templates.New(dir, templates.UserDir()), nil
+}
 
-  # Minimal header-only library
-  cppinit -name myheader -type header-only -minimal
+// parseInterspersed parses flags that may appear before, between or after
+// positional arguments and returns the positional arguments
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
 
-  # C library with Unity tests
-  cppinit -name myclib -lang c -type static -tests unity
+// flagGroup is a titled group of flags in a command's help
+type flagGroup struct {
+	title string
+	flags []string
+}
 
-  # Executable with specific features
-  cppinit -name myapp -tests catch2 -sanitizers -ci -vscode`)
+// printFlagGroups prints the flags of fs group by group, in the format of
+// flag.PrintDefaults
+func printFlagGroups(w io.Writer, fs *flag.FlagSet, groups []flagGroup) {
+	for _, g := range groups {
+		fmt.Fprintf(w, "\n%s:\n", g.title)
+		for _, name := range g.flags {
+			f := fs.Lookup(name)
+			typeName, usage := flag.UnquoteUsage(f)
+			left := "-" + f.Name
+			if typeName != "" {
+				left += " " + typeName
+			}
+			lines := strings.Split(usage, "\n")
+			if f.DefValue != "" && f.DefValue != "false" {
+				if typeName == "string" {
+					lines[len(lines)-1] += fmt.Sprintf(" (default %q)", f.DefValue)
+				} else if typeName == "" {
+					lines[len(lines)-1] += " (default " + f.DefValue + ")"
+				}
+			}
+			fmt.Fprintf(w, "  %-20s %s\n", left, lines[0])
+			for _, line := range lines[1:] {
+				fmt.Fprintf(w, "  %-20s %s\n", "", line)
+			}
+		}
+	}
 }
@@ -0,0 +1,547 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nikitalobanov12/cppinit/internal/scaffold"
+)
+
+// projectFlags are the flags of new and init
+type projectFlags struct {
+	fs *flag.FlagSet
+
+	name, description, author, language, std, projectType *string
+	testFw, pkgMgr, license                               *string
+
+	// Overrides of the names derived from the project name
+	identifier, macroPrefix, target, fileStem *string
+
+	// features holds the switch of every feature with a config field
+	features map[string]*bool
+
+	preset        *string
+	full, minimal *bool
+
+	output, dir, show *string
+	dryRun            *bool
+
+	configPath, saveConfig *string
+	force, skipExisting    *bool
+
+	templatesDir, packName *string
+	packOpts               packOptions
+
+	// defaultName is used when neither a flag nor the config file names the
+	// project
+	defaultName string
+	// existing is the code init found in the target directory
+	existing *scaffold.ExistingCode
+}
+
+// projectFlagGroups orders the flags of new and init in their help; the
+// feature flags join the group their feature names
+func projectFlagGroups() []flagGroup {
+	groups := []flagGroup{
+		{"Project Options", []string{"desc", "author", "lang", "std", "type", "license"}},
+		{"Naming", []string{"identifier", "macro-prefix", "target", "file-stem"}},
+		{scaffold.GroupDependencies, []string{"tests", "pkg"}},
+		{scaffold.GroupQuality, nil},
+		{scaffold.GroupDevOps, nil},
+		{"Presets", []string{"preset", "full", "minimal"}},
+		{"Config Files", []string{"config", "save-config"}},
+		{"Existing Files", []string{"force", "skip-existing"}},
+		{"Output", []string{"o", "dir", "json"}},
+		{"Dry Run", []string{"dry-run", "show"}},
+		{"Templates", []string{"templates", "pack", "pack-opt"}},
+	}
+	for _, f := range scaffold.Features {
+		for i := range groups {
+			if f.Field != nil && groups[i].title == f.Group {
+				groups[i].flags = append(groups[i].flags, f.Name)
+			}
+		}
+	}
+	return groups
+}
+
+// newProjectFlags defines the project flags on a new flag set
+func newProjectFlags(name string) *projectFlags {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	p := &projectFlags{fs: fs, packOpts: packOptions{}, features: make(map[string]*bool)}
+
+	p.name = fs.String("name", "", "Project name")
+	p.description = fs.String("desc", "", "Project description")
+	p.author = fs.String("author", "", "Author name for the license")
+	p.language = fs.String("lang", "c++", "Language: c, c++")
+	p.std = fs.String("std", "", "Standard (C: 89, 99, 11, 17, 23 | C++: 11, 14, 17, 20, 23)\nDefaults to C11 for C, C++17 for C++")
+	p.projectType = fs.String("type", "executable", "Project type: executable, static, header-only")
+	p.license = fs.String("license", "mit", "License: none, mit, apache2, gpl3, bsd3")
+
+	p.identifier = fs.String("identifier", "", "C/C++ identifier for the namespace, function prefix and test names\n(default: derived from the name, e.g. lib2d_engine for 2d-engine)")
+	p.macroPrefix = fs.String("macro-prefix", "", "Prefix of include guards and macros\n(default: the identifier in UPPER_SNAKE_CASE)")
+	p.target = fs.String("target", "", "CMake project and target name (default: derived from the name)")
+	p.fileStem = fs.String("file-stem", "", "Name of the include directory, header and source files\n(default: derived from the name)")
+
+	p.testFw = fs.String("tests", "none", "Test framework:\nC++: none, googletest, catch2, doctest | C: none, unity")
+	p.pkgMgr = fs.String("pkg", "none", "Package manager: none, vcpkg, conan, cpm")
+
+	defaults := scaffold.DefaultConfig()
+	for _, f := range scaffold.Features {
+		if f.Field != nil {
+			p.features[f.Name] = fs.Bool(f.Name, *f.Field(defaults), f.Description)
+		}
+	}
+
+	p.preset = fs.String("preset", "", "Start from a preset: minimal, full, library-publishing, embedded,\nci-strict or one in ~/.config/cppinit/presets (flags override it)")
+	p.full = fs.Bool("full", false, "Same as -preset full")
+	p.minimal = fs.Bool("minimal", false, "Same as -preset minimal")
+
+	p.configPath = fs.String("config", "", "Load project options from a YAML or JSON file\n(flags override file values)")
+	p.saveConfig = fs.String("save-config", "", "Write the resolved project options to a YAML or JSON file")
+
+	p.force = fs.Bool("force", false, "Overwrite files that already exist")
+	p.skipExisting = fs.Bool("skip-existing", false, "Keep files that already exist and write the rest")
+
+	p.output = fs.String("o", "", "Write the project to this directory, or to an archive if the path ends in\n.zip, .tar.gz or .tgz (- writes a .tar.gz to stdout)")
+	p.dir = fs.String("dir", "", "Write the project to this directory instead of ./<name>,\ne.g. libs/net/mynet")
+
+	addJSONFlag(fs, name)
+
+	p.dryRun = fs.Bool("dry-run", false, "Print the file tree with sizes without writing anything")
+	p.show = fs.String("show", "", "Print the planned content of one file, e.g. CMakeLists.txt")
+
+	p.templatesDir = fs.String("templates", "", "Directory of templates that replace the built-in ones\n(searched before ~/.config/cppinit/templates)")
+	p.packName = fs.String("pack", "", "Generate from a template pack, by installed name or directory")
+	fs.Var(p.packOpts, "pack-opt", "Set a `key=value` pack option (repeatable); the wizard asks for the rest")
+
+	return p
+}
+
+// runNew creates a project in a new directory named after it. Without a name
+// or config file it runs the interactive wizard.
+func runNew(args []string) error {
+	p := newProjectFlags("new")
+	p.fs.Usage = func() {
+		w := p.fs.Output()
+		fmt.Fprintln(w, "Usage: cppinit new [flags] [name]")
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Creates the project in ./<name>, or the directory given by -o or -dir. Without")
+		fmt.Fprintln(w, "a name or -config the interactive wizard asks for every option.")
+		printFlagGroups(w, p.fs, projectFlagGroups())
+	}
+
+	positional, err := parseInterspersed(p.fs, args)
+	if err != nil {
+		return err
+	}
+	switch {
+	case len(positional) > 1:
+		p.fs.Usage()
+		return fmt.Errorf("expected at most one project name")
+	case len(positional) == 1 && *p.name != "" && *p.name != positional[0]:
+		return fmt.Errorf("project name given twice: %s and -name %s", positional[0], *p.name)
+	case len(positional) == 1:
+		p.fs.Set("name", positional[0]) // counts as given, like -name
+	}
+
+	interactive := *p.name == "" && *p.configPath == ""
+	if interactive {
+		if given := p.givenOptions(); len(given) > 0 {
+			return fmt.Errorf("a project name is required when project options are given (%s); the wizard asks for them instead",
+				strings.Join(given, ", "))
+		}
+	}
+	return p.generate(interactive, "")
+}
+
+// givenOptions returns the project option flags given on the command line,
+// which the wizard would not use
+func (p *projectFlags) givenOptions() []string {
+	options := map[string]bool{}
+	for _, g := range projectFlagGroups() {
+		if g.title == "Presets" {
+			break // the groups from here on apply to the wizard too
+		}
+		for _, name := range g.flags {
+			options[name] = true
+		}
+	}
+	var given []string
+	p.fs.Visit(func(f *flag.Flag) {
+		if options[f.Name] {
+			given = append(given, "-"+f.Name)
+		}
+	})
+	return given
+}
+
+// runInit creates a project in an existing directory, named after the
+// directory unless -name is given
+func runInit(args []string) error {
+	p := newProjectFlags("init")
+	groups := projectFlagGroups()
+	groups[0].flags = append([]string{"name"}, groups[0].flags...)
+	p.fs.Usage = func() {
+		w := p.fs.Output()
+		fmt.Fprintln(w, "Usage: cppinit init [flags] [dir]")
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Turns dir (default: the current directory) into a project. The project name")
+		fmt.Fprintln(w, "defaults to the directory name. Sources in src/ and headers in include/ are")
+		fmt.Fprintln(w, "built instead of placeholders, and files that already exist are kept unless")
+		fmt.Fprintln(w, "-force is given.")
+		printFlagGroups(w, p.fs, groups)
+	}
+
+	positional, err := parseInterspersed(p.fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 1 {
+		p.fs.Usage()
+		return fmt.Errorf("expected at most one directory")
+	}
+	dir := "."
+	if len(positional) == 1 {
+		dir = positional[0]
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return fmt.Errorf("directory %s does not exist", dir)
+	}
+
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	p.defaultName = filepath.Base(abs)
+	if p.existing, err = scaffold.DetectCode(dir); err != nil {
+		return err
+	}
+	return p.generate(false, dir)
+}
+
+// generate resolves the project options from the flags, config file or
+// wizard and writes the project to outputDir, or ./<name> if it is empty
+func (p *projectFlags) generate(interactive bool, outputDir string) error {
+	if *p.force && *p.skipExisting {
+		return fmt.Errorf("-force and -skip-existing cannot be combined")
+	}
+
+	// -o names an archive or, like -dir, the project directory
+	archive := *p.output
+	dir := *p.dir
+	if archive != "" && archive != "-" && scaffold.ArchiveFormat(archive) == "" {
+		if dir != "" {
+			return fmt.Errorf("-o %s and -dir %s both name the output directory", archive, dir)
+		}
+		archive, dir = "", archive
+	}
+	switch {
+	case archive != "" && dir != "":
+		return fmt.Errorf("-o %s writes an archive; drop -dir %s", archive, dir)
+	case archive == "-" && interactive:
+		return fmt.Errorf("-o - writes the archive to stdout, which the wizard needs; pass a name or -config")
+	}
+	if report != nil {
+		switch {
+		case interactive:
+			return fmt.Errorf("-json needs a project name or -config; the wizard is interactive")
+		case *p.show != "":
+			return fmt.Errorf("-show cannot be combined with -json")
+		case archive == "-":
+			return fmt.Errorf("-o - cannot be combined with -json; both write to stdout")
+		}
+	}
+	if dir != "" {
+		if p.existing != nil {
+			return fmt.Errorf("init writes to the directory given as its argument; drop -o or -dir")
+		}
+		if err := scaffold.ValidateOutputDir(dir); err != nil {
+			return err
+		}
+		outputDir = dir
+	}
+
+	set, err := templateSet(*p.templatesDir)
+	if err != nil {
+		return err
+	}
+	// Existing files abort generation unless a policy was chosen
+	opts := scaffold.Options{Conflict: scaffold.ConflictAbort, Version: version, Templates: set}
+
+	var config *scaffold.Config
+	if interactive {
+		defaults, err := scaffold.UserDefaults()
+		if err != nil {
+			return err
+		}
+		name, err := p.presetName()
+		if err != nil {
+			return err
+		}
+		presets, err := scaffold.Presets()
+		if err != nil {
+			return err
+		}
+		if config, err = scaffold.RunPrompts(defaults, presets, name); err != nil {
+			return err
+		}
+		opts.Conflict = scaffold.ConflictPrompt
+		opts.Resolve = scaffold.PromptConflict
+	} else {
+		if config, err = p.config(); err != nil {
+			return err
+		}
+	}
+	if outputDir != "" {
+		config.OutputDir = outputDir
+	}
+
+	if *p.packName != "" {
+		config.Pack = *p.packName
+	}
+	if err := p.resolvePack(config, interactive); err != nil {
+		return err
+	}
+
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	if note := config.RenamedTarget(); note != "" && !interactive {
+		warn("%s; set -target to choose another name", note)
+	}
+
+	if *p.force {
+		opts.Conflict = scaffold.ConflictForce
+	} else if *p.skipExisting || p.existing != nil {
+		opts.Conflict = scaffold.ConflictSkip
+	}
+
+	if *p.saveConfig != "" {
+		if err := scaffold.SaveConfigFile(*p.saveConfig, config); err != nil {
+			return err
+		}
+	}
+
+	if *p.show != "" || *p.dryRun {
+		plan, err := scaffold.BuildPlan(config, opts.Templates)
+		if err != nil {
+			return err
+		}
+		plan.AddManifest(config, version)
+		if report != nil {
+			report.DryRun = true
+			report.OutputDir = config.OutputDir
+			report.Config = config
+			report.SetPlan(plan)
+			return nil
+		}
+		if *p.show != "" {
+			return scaffold.PrintPlanFile(plan, *p.show)
+		}
+		scaffold.PrintPlan(config, plan)
+		return nil
+	}
+
+	if archive != "" {
+		plan, err := scaffold.BuildPlan(config, opts.Templates)
+		if err != nil {
+			return err
+		}
+		plan.AddManifest(config, version)
+		if archive == "-" {
+			return scaffold.WriteArchive(os.Stdout, "tar.gz", config.ProjectName, plan)
+		}
+		if err := scaffold.SaveArchive(archive, config.ProjectName, plan, *p.force); err != nil {
+			return err
+		}
+		if report != nil {
+			report.Archive = archive
+			report.Config = config
+			report.SetPlan(plan)
+			return nil
+		}
+		scaffold.PrintArchived(archive, plan)
+		return nil
+	}
+
+	result, err := scaffold.Generate(config, opts)
+	if err != nil {
+		return err
+	}
+
+	if report != nil {
+		report.OutputDir = config.OutputDir
+		report.Config = config
+		for _, filename := range result.Written {
+			report.Files = append(report.Files, scaffold.FileReport{Path: filename, Size: result.Sizes[filename]})
+		}
+		report.Skipped = result.Skipped
+		report.NextSteps = scaffold.NextSteps(config)
+		return nil
+	}
+	scaffold.PrintSuccess(config, result)
+	return nil
+}
+
+// presetName returns the preset chosen by -preset, -full or -minimal
+func (p *projectFlags) presetName() (string, error) {
+	names := []string{}
+	if *p.preset != "" {
+		names = append(names, *p.preset)
+	}
+	if *p.full {
+		names = append(names, "full")
+	}
+	if *p.minimal {
+		names = append(names, "minimal")
+	}
+	if len(names) > 1 {
+		return "", fmt.Errorf("-preset, -full and -minimal cannot be combined")
+	}
+	if len(names) == 0 {
+		return "", nil
+	}
+	return names[0], nil
+}
+
+// flagKeys maps the project flags to the config keys they set where the
+// names differ
+var flagKeys = map[string]string{
+	"lang": "language",
+	"std":  "standard",
+	"desc": "description",
+	"pkg":  "package_manager",
+}
+
+// config builds the project options from the preset, the config file and the
+// flags given on the command line
+func (p *projectFlags) config() (*scaffold.Config, error) {
+	// Layered as user defaults < preset < config file < flags
+	config, err := scaffold.UserDefaults()
+	if err != nil {
+		return nil, err
+	}
+	given := make(map[string]bool) // config keys set by the config file or flags
+	if *p.configPath != "" {
+		if err := scaffold.ApplyConfigFile(config, *p.configPath); err != nil {
+			return nil, err
+		}
+		keys, err := scaffold.ConfigFileKeys(*p.configPath)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			given[key] = true
+		}
+	}
+
+	p.fs.Visit(func(f *flag.Flag) {
+		key, ok := flagKeys[f.Name]
+		if !ok {
+			key = strings.ReplaceAll(f.Name, "-", "_")
+		}
+		given[key] = true
+		switch f.Name {
+		case "name":
+			config.ProjectName = *p.name
+		case "desc":
+			config.Description = *p.description
+		case "author":
+			config.AuthorName = *p.author
+		case "lang":
+			if config.Language != *p.language {
+				config.Standard = "" // a default standard may not exist for the other language
+			}
+			config.Language = *p.language
+		case "std":
+			config.Standard = *p.std
+		case "type":
+			config.ProjectType = *p.projectType
+		case "tests":
+			config.TestFramework = *p.testFw
+		case "pkg":
+			config.PackageManager = *p.pkgMgr
+		case "license":
+			config.License = *p.license
+		case "identifier":
+			config.Identifier = *p.identifier
+		case "macro-prefix":
+			config.MacroPrefix = *p.macroPrefix
+		case "target":
+			config.Target = *p.target
+		case "file-stem":
+			config.FileStem = *p.fileStem
+		default:
+			if on, ok := p.features[f.Name]; ok {
+				feature, _ := scaffold.LookupFeature(f.Name)
+				feature.Set(config, *on)
+			}
+		}
+	})
+
+	// The preset fills in what the config file and flags leave open
+	name, err := p.presetName()
+	if err != nil {
+		return nil, err
+	}
+	if name != "" {
+		preset, err := scaffold.FindPreset(name)
+		if err != nil {
+			return nil, err
+		}
+		skip := func(key string) bool { return given[key] }
+		if err := preset.Apply(config, skip); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.ProjectName == "" {
+		config.ProjectName = p.defaultName
+	}
+	if config.ProjectName == "" {
+		return nil, fmt.Errorf("no project name given; pass a name or set name in %s", *p.configPath)
+	}
+	if p.existing != nil {
+		fromFile := *p.configPath != ""
+		p.existing.Apply(config, given["language"] || fromFile, given["type"] || fromFile)
+	}
+	config.ApplyLanguageDefaults()
+	return config, nil
+}
+
+// resolvePack fills in the option values of the project's template pack,
+// asking for them in the wizard
+func (p *projectFlags) resolvePack(config *scaffold.Config, interactive bool) error {
+	if config.Pack == "" {
+		return nil
+	}
+	pack, err := scaffold.FindPack(config.Pack)
+	if err != nil {
+		return err
+	}
+	if config.PackOptions == nil {
+		config.PackOptions = make(map[string]any)
+	}
+	for k, v := range p.packOpts {
+		config.PackOptions[k] = v
+	}
+	if interactive {
+		config.PackOptions, err = scaffold.PromptPackOptions(pack, config.PackOptions)
+	} else {
+		config.PackOptions, err = pack.ResolveOptions(config.PackOptions)
+	}
+	if err != nil {
+		return err
+	}
+	// Record where the pack lives so upgrade and add find it again
+	if config.Pack != pack.Name {
+		if config.Pack, err = filepath.Abs(pack.Dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/nikitalobanov12/cppinit/internal/scaffold"
+)
+
+// report is the -json document of the running command, nil while the
+// command prints its human output
+var report *scaffold.Report
+
+// warnings are printed to stderr after the human output, or reported
+var warnings []string
+
+// warn records a warning for the user
+func warn(format string, args ...any) {
+	warnings = append(warnings, fmt.Sprintf(format, args...))
+}
+
+// jsonFlag is the -json flag of the named command
+type jsonFlag string
+
+func (j jsonFlag) String() string {
+	return "false"
+}
+
+func (j jsonFlag) IsBoolFlag() bool {
+	return true
+}
+
+func (j jsonFlag) Set(value string) error {
+	on, err := strconv.ParseBool(value)
+	if err != nil {
+		return err
+	}
+	report = nil
+	if on {
+		report = scaffold.NewReport(string(j), version)
+	}
+	return nil
+}
+
+// addJSONFlag defines -json on the flag set of command
+func addJSONFlag(fs *flag.FlagSet, command string) {
+	fs.Var(jsonFlag(command), "json", "Print one JSON document instead of the human output")
+}
+
+// finish prints the report or the warnings once a command ran and returns
+// the exit status
+func finish(err error) int {
+	if report == nil {
+		for _, w := range warnings {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	report.OK = true
+	if err != nil {
+		report.SetError(err)
+	}
+	report.Warnings = append(report.Warnings, warnings...)
+	if werr := report.Write(os.Stdout); werr != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to write report: %v\n", werr)
+		return 1
+	}
+	if err != nil {
+		return 1
+	}
+	return 0
+}
+
+// reportChanges reports the files add, remove or upgrade touched in dir
+func reportChanges(dir string, changes []scaffold.FileChange, dryRun bool) {
+	report.OutputDir = dir
+	report.DryRun = dryRun
+	report.Changes = changes
+
+	conflicts := 0
+	for _, c := range changes {
+		if c.Action == scaffold.ChangeConflict || c.Action == scaffold.ChangeRejected {
+			conflicts++
+		}
+	}
+	if conflicts > 0 {
+		warn("%d file(s) need manual attention", conflicts)
+	}
+}
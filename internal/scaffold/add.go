@@ -0,0 +1,296 @@
+package scaffold
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/nikitalobanov12/cppinit/internal/templates"
+)
+
+// FeatureOptions controls AddFeature and RemoveFeature
+type FeatureOptions struct {
+	// Version is the cppinit version recorded in the updated manifest
+	Version string
+
+	// TestFramework selects the framework when adding tests; empty picks
+	// the default for the project's language
+	TestFramework string
+
+	// Conflict decides what happens to feature files that already exist
+	// with different content (ConflictAbort, ConflictForce or ConflictSkip)
+	Conflict ConflictPolicy
+
+	// DryRun reports the changes without writing anything
+	DryRun bool
+	// Templates replace built-in templates; nil renders only the built-in ones
+	Templates *templates.Set
+}
+
+// AddFeature enables a feature in the existing project in dir. It writes
+// the feature's files, inserts its include() and enable_*() calls into the
+// root CMakeLists.txt, adds its presets to CMakePresets.json and refreshes
+// other generated files that mention it if they were not modified locally.
+func AddFeature(dir, name string, opts FeatureOptions) ([]FileChange, error) {
+	m, err := ReadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	f, err := LookupFeature(name)
+	if err != nil {
+		return nil, err
+	}
+	if f.Enabled(m.Config) {
+		return nil, fmt.Errorf("%s is already enabled in %s", f.Name, dir)
+	}
+
+	if opts.TestFramework != "" && f.Name != "tests" {
+		return nil, fmt.Errorf("a test framework (%s) can only be chosen when adding tests, not %s", opts.TestFramework, f.Name)
+	}
+
+	updated := *m.Config
+	if opts.TestFramework != "" {
+		updated.TestFramework = opts.TestFramework
+	}
+	f.Set(&updated, true)
+	if err := updated.Validate(); err != nil {
+		return nil, fmt.Errorf("cannot add %s: %w", f.Name, err)
+	}
+
+	return applyFeature(dir, m, &updated, f, true, opts)
+}
+
+// RemoveFeature disables a feature in the existing project in dir. It
+// deletes the files the feature owns, strips its snippets from the root
+// CMakeLists.txt and its presets from CMakePresets.json, and refreshes other
+// generated files that mention it. Files modified locally are kept and
+// reported so they can be cleaned up by hand.
+func RemoveFeature(dir, name string, opts FeatureOptions) ([]FileChange, error) {
+	m, err := ReadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	f, err := LookupFeature(name)
+	if err != nil {
+		return nil, err
+	}
+	if !f.Enabled(m.Config) {
+		return nil, fmt.Errorf("%s is not enabled in %s", f.Name, dir)
+	}
+
+	updated := *m.Config
+	f.Set(&updated, false)
+
+	return applyFeature(dir, m, &updated, f, false, opts)
+}
+
+// applyFeature moves the project in dir from its recorded config to
+// updated, adding or removing f. Untouched files are re-rendered or
+// deleted. A locally modified root CMakeLists.txt or CMakePresets.json is
+// edited in place; other modified files are left alone.
+func applyFeature(dir string, m *Manifest, updated *Config, f *Feature, add bool, opts FeatureOptions) ([]FileChange, error) {
+	before, err := BuildPlan(m.Config, opts.Templates)
+	if err != nil {
+		return nil, err
+	}
+	after, err := BuildPlan(updated, opts.Templates)
+	if err != nil {
+		return nil, err
+	}
+	bases, err := m.MergeBases()
+	if err != nil {
+		return nil, err
+	}
+
+	writes := &Plan{Files: make(map[string]string)}
+	// The files the new manifest records: the old records, updated for the
+	// files written from the new render. Files edited in place or kept keep
+	// their old record, so that local changes are never taken for generated
+	// ones.
+	recorded := &Plan{Files: make(map[string]string, len(m.Files))}
+	for filename := range m.Files {
+		if base, ok := bases[filename]; ok {
+			recorded.Files[filename] = base
+		}
+	}
+	var removals, conflicts []string
+	var changes []FileChange
+
+	paths := after.Paths()
+	for _, filename := range before.Paths() {
+		if _, ok := after.Files[filename]; !ok {
+			paths = append(paths, filename)
+		}
+	}
+	sort.Strings(paths)
+
+	for _, filename := range paths {
+		old, wasGenerated := before.Files[filename]
+		next, isGenerated := after.Files[filename]
+		if wasGenerated && isGenerated && old == next {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, filename))
+		exists := err == nil
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("failed to read %s: %w", filename, err)
+		}
+		current := string(data)
+		record, tracked := m.Files[filename]
+		untouched := exists && tracked && hashContent(data) == record.SHA256
+
+		change := FileChange{Path: filename}
+		switch {
+		case filename == "CMakeLists.txt":
+			if !exists {
+				return nil, fmt.Errorf("%s has no CMakeLists.txt to update", dir)
+			}
+			if untouched {
+				writes.Files[filename], recorded.Files[filename] = next, next
+				change.Action = ChangeUpdated
+				break
+			}
+			var edited string
+			if add {
+				if edited, err = addCMakeSnippets(current, f); err != nil {
+					return nil, err
+				}
+			} else {
+				var clean bool
+				if edited, clean = removeCMakeSnippets(current, f); !clean {
+					change.Reason = fmt.Sprintf("some %s lines were modified locally, remove them by hand", f.Name)
+				}
+			}
+			switch {
+			case edited != current:
+				writes.Files[filename] = edited
+				change.Action = ChangeUpdated
+			case change.Reason != "":
+				change.Action = ChangeKept
+			default:
+				continue
+			}
+
+		case filename == "CMakePresets.json":
+			if !exists {
+				change.Action, change.Reason = ChangeKept, "deleted locally"
+				break
+			}
+			if untouched {
+				writes.Files[filename], recorded.Files[filename] = next, next
+				change.Action = ChangeUpdated
+				break
+			}
+			edited, err := syncPresets(current, old, next)
+			if err != nil {
+				return nil, err
+			}
+			if edited == current {
+				continue
+			}
+			writes.Files[filename] = edited
+			change.Action = ChangeUpdated
+
+		case !isGenerated:
+			// Owned by the feature being removed
+			delete(recorded.Files, filename)
+			if !exists {
+				continue
+			}
+			if !untouched {
+				change.Action, change.Reason = ChangeKept, "modified locally, delete it by hand"
+				break
+			}
+			removals = append(removals, filename)
+			change.Action = ChangeRemoved
+
+		case !wasGenerated:
+			// Owned by the feature being added
+			if exists && current == next {
+				recorded.Files[filename] = next
+				continue
+			}
+			if exists {
+				switch opts.Conflict {
+				case ConflictForce:
+				case ConflictSkip:
+					change.Action, change.Reason = ChangeKept, "already exists"
+					changes = append(changes, change)
+					continue
+				default:
+					conflicts = append(conflicts, filename)
+					continue
+				}
+			}
+			writes.Files[filename], recorded.Files[filename] = next, next
+			change.Action = ChangeCreated
+
+		default:
+			// Another generated file mentions the feature (README, CI, ...)
+			if !exists {
+				continue
+			}
+			if !untouched {
+				change.Action, change.Reason = ChangeKept, "modified locally, update it by hand"
+				break
+			}
+			writes.Files[filename], recorded.Files[filename] = next, next
+			change.Action = ChangeUpdated
+		}
+		changes = append(changes, change)
+	}
+
+	if len(conflicts) > 0 {
+		return nil, &ConflictError{OutputDir: dir, Paths: conflicts}
+	}
+	if opts.DryRun {
+		return changes, nil
+	}
+
+	writes.Files[ManifestFile] = NewManifest(updated, opts.Version, recorded).Render()
+	if err := WritePlan(dir, writes); err != nil {
+		return nil, err
+	}
+	for _, filename := range removals {
+		if err := os.Remove(filepath.Join(dir, filename)); err != nil {
+			return nil, fmt.Errorf("failed to remove %s: %w", filename, err)
+		}
+	}
+	removeEmptyDirs(dir, removals)
+
+	return changes, nil
+}
+
+// removeEmptyDirs deletes the directories of removed files that are left
+// empty, innermost first
+func removeEmptyDirs(root string, removed []string) {
+	dirs := make(map[string]bool)
+	for _, filename := range removed {
+		for d := filepath.Dir(filename); d != "."; d = filepath.Dir(d) {
+			dirs[d] = true
+		}
+	}
+	sorted := make([]string, 0, len(dirs))
+	for d := range dirs {
+		sorted = append(sorted, d)
+	}
+	// Longer paths first so children go before their parents
+	sort.Slice(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+	for _, d := range sorted {
+		// Remove fails on non-empty directories, which is what we want
+		os.Remove(filepath.Join(root, d))
+	}
+}
+
+// PrintFeatureChanges prints the files touched while adding or removing a
+// feature
+func PrintFeatureChanges(summary string, changes []FileChange, dryRun bool) {
+	printChanges(changes, dryRun)
+	if !dryRun {
+		fmt.Println(successStyle.Render("✓ " + summary))
+	}
+}
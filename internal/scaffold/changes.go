@@ -0,0 +1,63 @@
+package scaffold
+
+import "fmt"
+
+// ChangeAction is what happened to one file of an existing project
+type ChangeAction string
+
+const (
+	ChangeUpdated  ChangeAction = "updated"  // unmodified file replaced by a new render
+	ChangeCreated  ChangeAction = "created"  // file that did not exist before
+	ChangeMerged   ChangeAction = "merged"   // local and template changes merged cleanly
+	ChangeConflict ChangeAction = "conflict" // conflict markers written into the file
+	ChangeRejected ChangeAction = "rejected" // template change written to <file>.rej
+	ChangeRemoved  ChangeAction = "removed"  // unmodified file deleted
+	ChangeKept     ChangeAction = "kept"     // locally deleted or modified file left alone
+)
+
+// FileChange describes one file touched (or deliberately left alone) in an
+// existing project
+type FileChange struct {
+	Path      string       `json:"path"`
+	Action    ChangeAction `json:"action"`
+	Conflicts int          `json:"conflicts,omitempty"` // conflicting regions for ChangeConflict and ChangeRejected
+	Reason    string       `json:"reason,omitempty"`    // why the file was kept or needs a manual edit
+}
+
+// printChanges lists changed files and returns how many need manual
+// attention
+func printChanges(changes []FileChange, dryRun bool) int {
+	if dryRun {
+		fmt.Println(titleStyle.Render("Dry run: nothing was written"))
+	}
+
+	conflicts := 0
+	for _, c := range changes {
+		label := fmt.Sprintf("%-8s", c.Action)
+		switch c.Action {
+		case ChangeConflict, ChangeRejected:
+			conflicts++
+			label = pathStyle.Render(label)
+		case ChangeKept:
+			label = dimStyle.Render(label)
+		}
+
+		line := fmt.Sprintf("  %s %s", label, c.Path)
+		switch c.Action {
+		case ChangeConflict:
+			line += fmt.Sprintf(" (%d conflict(s), look for <<<<<<< markers)", c.Conflicts)
+		case ChangeRejected:
+			line += fmt.Sprintf(" (%d conflict(s), see %s.rej)", c.Conflicts, c.Path)
+		}
+		if c.Reason != "" {
+			line += " (" + c.Reason + ")"
+		}
+		fmt.Println(line)
+	}
+
+	fmt.Println()
+	if conflicts > 0 {
+		fmt.Println(pathStyle.Render(fmt.Sprintf("%d file(s) need manual attention", conflicts)))
+	}
+	return conflicts
+}
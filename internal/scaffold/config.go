@@ -2,33 +2,48 @@ package scaffold
 
 // Config holds all the project configuration options
 type Config struct {
-	ProjectName    string
-	Description    string
-	Language       string // "c" or "c++"
-	Standard       string // C: "89", "99", "11", "17", "23" | C++: "11", "14", "17", "20", "23"
-	ProjectType    string // "executable", "static", "header-only"
-	TestFramework  string // "none", "googletest", "catch2", "doctest" (C++ only), "unity" (C only)
-	PackageManager string // "none", "vcpkg", "conan", "cpm"
-	License        string // "none", "mit", "apache2", "gpl3", "bsd3"
+	ProjectName    string `json:"name,omitempty" yaml:"name,omitempty"`
+	Description    string `json:"description,omitempty" yaml:"description,omitempty"`
+	Language       string `json:"language" yaml:"language"`               // "c" or "c++"
+	Standard       string `json:"standard" yaml:"standard"`               // C: "89", "99", "11", "17", "23" | C++: "11", "14", "17", "20", "23"
+	ProjectType    string `json:"type" yaml:"type"`                       // "executable", "static", "header-only"
+	TestFramework  string `json:"tests" yaml:"tests"`                     // "none", "googletest", "catch2", "doctest" (C++ only), "unity" (C only)
+	PackageManager string `json:"package_manager" yaml:"package_manager"` // "none", "vcpkg", "conan", "cpm"
+	License        string `json:"license" yaml:"license"`                 // "none", "mit", "apache2", "gpl3", "bsd3"
 
 	// Feature flags
-	UseClangFormat   bool
-	UseClangTidy     bool
-	UseSanitizers    bool
-	UseCoverage      bool
-	UseDoxygen       bool
-	UseDocker        bool
-	UsePreCommit     bool
-	IncludeCI        bool
-	IncludeVSCode    bool
-	IncludeBenchmark bool
+	UseClangFormat   bool `json:"clang_format" yaml:"clang_format"`
+	UseClangTidy     bool `json:"clang_tidy" yaml:"clang_tidy"`
+	UseSanitizers    bool `json:"sanitizers" yaml:"sanitizers"`
+	UseCoverage      bool `json:"coverage" yaml:"coverage"`
+	UseDoxygen       bool `json:"doxygen" yaml:"doxygen"`
+	UseDocker        bool `json:"docker" yaml:"docker"`
+	UsePreCommit     bool `json:"precommit" yaml:"precommit"`
+	IncludeCI        bool `json:"ci" yaml:"ci"`
+	IncludeVSCode    bool `json:"vscode" yaml:"vscode"`
+	IncludeBenchmark bool `json:"benchmark" yaml:"benchmark"`
 
 	// Metadata
-	AuthorName  string
-	AuthorEmail string
-	GitRepo     string
+	AuthorName  string `json:"author,omitempty" yaml:"author,omitempty"`
+	AuthorEmail string `json:"email,omitempty" yaml:"email,omitempty"`
+	GitRepo     string `json:"repo,omitempty" yaml:"repo,omitempty"`
 
-	OutputDir string
+	// Overrides of the names derived from the project name, see Names
+	Identifier  string `json:"identifier,omitempty" yaml:"identifier,omitempty"`
+	MacroPrefix string `json:"macro_prefix,omitempty" yaml:"macro_prefix,omitempty"`
+	Target      string `json:"target,omitempty" yaml:"target,omitempty"`
+	FileStem    string `json:"file_stem,omitempty" yaml:"file_stem,omitempty"`
+
+	// Code that existed before cppinit init: Sources are built instead of
+	// the placeholder sources, and OwnHeaders drops the placeholder header
+	Sources    []string `json:"sources,omitempty" yaml:"sources,omitempty"`
+	OwnHeaders bool     `json:"own_headers,omitempty" yaml:"own_headers,omitempty"`
+
+	// Template pack the project is generated from, and its option values
+	Pack        string         `json:"pack,omitempty" yaml:"pack,omitempty"`
+	PackOptions map[string]any `json:"pack_options,omitempty" yaml:"pack_options,omitempty"`
+
+	OutputDir string `json:"-" yaml:"-"`
 }
 
 // DefaultConfig returns a config with sensible defaults
@@ -45,6 +60,38 @@ func DefaultConfig() *Config {
 	}
 }
 
+// ApplyLanguageDefaults fills in the standard, description and output
+// directory when they were left empty
+func (c *Config) ApplyLanguageDefaults() {
+	if c.Standard == "" {
+		c.Standard = c.DefaultStandard()
+	}
+	if c.Description == "" {
+		if c.IsC() {
+			c.Description = "A modern C project"
+		} else {
+			c.Description = "A modern C++ project"
+		}
+	}
+	if c.OutputDir == "" {
+		c.OutputDir = c.ProjectName
+	}
+}
+
+// HasExistingCode returns true if the project builds code that existed
+// before cppinit init, so no placeholder API can be assumed
+func (c *Config) HasExistingCode() bool {
+	return len(c.Sources) > 0 || c.OwnHeaders
+}
+
+// DefaultStandard returns the standard used when none is chosen
+func (c *Config) DefaultStandard() string {
+	if c.IsC() {
+		return "11" // C11 default
+	}
+	return "17" // C++17 default
+}
+
 // IsC returns true if the project is a C project
 func (c *Config) IsC() bool {
 	return c.Language == "c"
@@ -0,0 +1,115 @@
+package scaffold
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigSchemaVersion is the version of the config file format written by
+// SaveConfigFile. Files with a newer version are rejected.
+const ConfigSchemaVersion = 1
+
+// configFile is the on-disk layout of a project config file
+type configFile struct {
+	Schema  int `json:"schema" yaml:"schema"`
+	*Config `yaml:",inline"`
+}
+
+// isJSONPath reports whether a config path should be read and written as JSON
+func isJSONPath(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".json")
+}
+
+// ConfigFileKeys returns the keys a YAML or JSON project config sets
+func ConfigFileKeys(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	values := map[string]any{}
+	if isJSONPath(path) {
+		err = json.Unmarshal(data, &values)
+	} else {
+		err = yaml.Unmarshal(data, &values)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// ApplyConfigFile reads a YAML or JSON project config over config. When the
+// file switches the language without naming a standard, the standard is
+// cleared so that it follows the new language.
+func ApplyConfigFile(config *Config, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+
+	language, standard := config.Language, config.Standard
+	file := configFile{Config: config}
+
+	if isJSONPath(path) {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&file)
+	} else {
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(&file)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+
+	switch {
+	case file.Schema == 0:
+		return fmt.Errorf("config %s has no schema version; add \"schema: %d\"", path, ConfigSchemaVersion)
+	case file.Schema > ConfigSchemaVersion:
+		return fmt.Errorf("config %s uses schema %d, but this cppinit only understands schema %d; please upgrade cppinit",
+			path, file.Schema, ConfigSchemaVersion)
+	}
+
+	if config.Language != language && config.Standard == standard {
+		config.Standard = ""
+	}
+	return nil
+}
+
+// SaveConfigFile writes the config as JSON when path ends in .json and as
+// YAML otherwise
+func SaveConfigFile(path string, config *Config) error {
+	file := configFile{Schema: ConfigSchemaVersion, Config: config}
+
+	var data []byte
+	var err error
+	if isJSONPath(path) {
+		data, err = json.MarshalIndent(file, "", "  ")
+		data = append(data, '\n')
+	} else {
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		err = enc.Encode(file)
+		data = buf.Bytes()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return nil
+}
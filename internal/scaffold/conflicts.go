@@ -0,0 +1,133 @@
+package scaffold
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nikitalobanov12/cppinit/internal/templates"
+)
+
+// ConflictPolicy decides what happens to planned files that already exist
+type ConflictPolicy string
+
+const (
+	ConflictAbort  ConflictPolicy = "abort"  // refuse to write anything (default)
+	ConflictForce  ConflictPolicy = "force"  // overwrite existing files
+	ConflictSkip   ConflictPolicy = "skip"   // keep existing files, write the rest
+	ConflictPrompt ConflictPolicy = "prompt" // ask for every conflicting file
+)
+
+// Options controls how Generate writes a project to disk
+type Options struct {
+	Conflict ConflictPolicy
+
+	// Version is the cppinit version recorded in the project's manifest
+	Version string
+
+	// Resolve is asked about every conflicting file when Conflict is
+	// ConflictPrompt and returns true to overwrite it
+	Resolve func(filename, existing, planned string) (bool, error)
+	// Templates replace built-in templates; nil renders only the built-in ones
+	Templates *templates.Set
+}
+
+// Result reports what Generate did
+type Result struct {
+	Written []string
+	Skipped []string
+	Sizes   map[string]int // bytes of every written file
+}
+
+// Conflict is a planned file that already exists with different content
+type Conflict struct {
+	Path     string
+	Existing string
+	Planned  string
+}
+
+// ConflictError is returned when existing files block generation
+type ConflictError struct {
+	OutputDir string
+	Paths     []string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%d file(s) already exist in %s: %s\nre-run with -force to overwrite them or -skip-existing to keep them",
+		len(e.Paths), e.OutputDir, strings.Join(e.Paths, ", "))
+}
+
+// FindConflicts returns the planned files that already exist below
+// outputDir with different content, in sorted order. Files whose content
+// is identical are not conflicts.
+func FindConflicts(outputDir string, plan *Plan) ([]Conflict, error) {
+	var conflicts []Conflict
+	for _, filename := range plan.Paths() {
+		path := filepath.Join(outputDir, filename)
+		existing, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", filename, err)
+		}
+		if string(existing) == plan.Files[filename] {
+			continue
+		}
+		conflicts = append(conflicts, Conflict{
+			Path:     filename,
+			Existing: string(existing),
+			Planned:  plan.Files[filename],
+		})
+	}
+	return conflicts, nil
+}
+
+// ResolveConflicts applies the conflict policy to the plan, removing every
+// file that should be kept as it is. It returns the skipped paths.
+func ResolveConflicts(outputDir string, plan *Plan, opts Options) ([]string, error) {
+	conflicts, err := FindConflicts(outputDir, plan)
+	if err != nil {
+		return nil, err
+	}
+	if len(conflicts) == 0 {
+		return nil, nil
+	}
+
+	var skipped []string
+	switch opts.Conflict {
+	case ConflictForce:
+		return nil, nil
+	case ConflictSkip:
+		for _, c := range conflicts {
+			skipped = append(skipped, c.Path)
+		}
+	case ConflictPrompt:
+		if opts.Resolve == nil {
+			return nil, fmt.Errorf("conflict policy %q needs a resolver", opts.Conflict)
+		}
+		for _, c := range conflicts {
+			overwrite, err := opts.Resolve(c.Path, c.Existing, c.Planned)
+			if err != nil {
+				return nil, err
+			}
+			if !overwrite {
+				skipped = append(skipped, c.Path)
+			}
+		}
+	default:
+		paths := make([]string, 0, len(conflicts))
+		for _, c := range conflicts {
+			paths = append(paths, c.Path)
+		}
+		return nil, &ConflictError{OutputDir: outputDir, Paths: paths}
+	}
+
+	for _, filename := range skipped {
+		delete(plan.Files, filename)
+	}
+	return skipped, nil
+}
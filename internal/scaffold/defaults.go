@@ -0,0 +1,332 @@
+package scaffold
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultsFile is the user defaults file in the cppinit config directory
+const DefaultsFile = "defaults.yaml"
+
+// EnvPrefix starts the environment variables that override user defaults,
+// e.g. CPPINIT_LICENSE for the license key
+const EnvPrefix = "CPPINIT_"
+
+// Where a default comes from
+const (
+	SourceBuiltin = "builtin"
+	SourceFile    = "file"
+	SourceEnv     = "env"
+)
+
+// Default is the effective default of one config key
+type Default struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"` // SourceBuiltin, SourceFile or SourceEnv
+}
+
+// setting is a config key that can be given a default
+type setting struct {
+	key   string
+	valid []string // allowed values, nil for free text
+	field func(c *Config) any
+}
+
+// settings lists the keys of the defaults file, which are the keys of
+// project config files that make sense for more than one project, followed
+// by the switches of the features
+var settings = append([]setting{
+	{"author", nil, func(c *Config) any { return &c.AuthorName }},
+	{"email", nil, func(c *Config) any { return &c.AuthorEmail }},
+	{"language", Languages, func(c *Config) any { return &c.Language }},
+	{"standard", append(slices.Clone(CStandards[:2]), CppStandards...), func(c *Config) any { return &c.Standard }},
+	{"type", ProjectTypes, func(c *Config) any { return &c.ProjectType }},
+	{"tests", append(slices.Clone(CppTestFrameworks), "unity"), func(c *Config) any { return &c.TestFramework }},
+	{"package_manager", PackageManagers, func(c *Config) any { return &c.PackageManager }},
+	{"license", Licenses, func(c *Config) any { return &c.License }},
+	{"pack", nil, func(c *Config) any { return &c.Pack }},
+}, featureSettings()...)
+
+// featureSettings returns a setting for every feature with a Field
+func featureSettings() []setting {
+	var list []setting
+	for _, f := range Features {
+		if f.Field != nil {
+			list = append(list, setting{f.Key(), nil, func(c *Config) any { return f.Field(c) }})
+		}
+	}
+	return list
+}
+
+// findSetting looks up a key of the defaults file
+func findSetting(key string) (*setting, error) {
+	keys := make([]string, 0, len(settings))
+	for i := range settings {
+		if settings[i].key == key {
+			return &settings[i], nil
+		}
+		keys = append(keys, settings[i].key)
+	}
+	return nil, fmt.Errorf("unknown key %q (%s)", key, suggest(key, keys))
+}
+
+// get returns the value of the setting in c as text
+func (s *setting) get(c *Config) string {
+	switch v := s.field(c).(type) {
+	case *string:
+		return *v
+	case *bool:
+		return strconv.FormatBool(*v)
+	}
+	return ""
+}
+
+// set checks value and stores it in c
+func (s *setting) set(c *Config, value string) error {
+	switch v := s.field(c).(type) {
+	case *string:
+		if s.valid != nil && !slices.Contains(s.valid, value) {
+			return fmt.Errorf("unknown %s %q (%s)", s.key, value, suggest(value, s.valid))
+		}
+		*v = value
+	case *bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%s must be true or false, got %q", s.key, value)
+		}
+		*v = b
+	}
+	return nil
+}
+
+// envName returns the environment variable of the setting
+func (s *setting) envName() string {
+	return EnvPrefix + strings.ToUpper(s.key)
+}
+
+// DefaultsPath returns the user defaults file,
+// ~/.config/cppinit/defaults.yaml on Linux ($XDG_CONFIG_HOME is honoured)
+func DefaultsPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate the config directory: %w", err)
+	}
+	return filepath.Join(dir, "cppinit", DefaultsFile), nil
+}
+
+// LoadDefaults reads the values of the user defaults file. A missing file
+// holds no values.
+func LoadDefaults() (map[string]string, error) {
+	path, err := DefaultsPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read defaults: %w", err)
+	}
+
+	values := map[string]string{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse defaults %s: %w", path, err)
+	}
+	if schema, ok := values["schema"]; ok {
+		if n, err := strconv.Atoi(schema); err != nil || n > ConfigSchemaVersion {
+			return nil, fmt.Errorf("defaults %s uses schema %s, but this cppinit only understands schema %d; please upgrade cppinit",
+				path, schema, ConfigSchemaVersion)
+		}
+		delete(values, "schema")
+	}
+	for key, value := range values {
+		s, err := findSetting(key)
+		if err != nil {
+			return nil, fmt.Errorf("defaults %s: %w", path, err)
+		}
+		if err := s.set(DefaultConfig(), value); err != nil {
+			return nil, fmt.Errorf("defaults %s: %w", path, err)
+		}
+	}
+	return values, nil
+}
+
+// SaveDefaults replaces the user defaults file with values
+func SaveDefaults(values map[string]string) error {
+	path, err := DefaultsPath()
+	if err != nil {
+		return err
+	}
+
+	// Flags are written as YAML booleans, everything else as strings
+	typed := make(map[string]any, len(values))
+	for key, value := range values {
+		typed[key] = value
+		if b, err := strconv.ParseBool(value); err == nil {
+			if s, _ := findSetting(key); s != nil && s.valid == nil {
+				typed[key] = b
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "schema: %d\n", ConfigSchemaVersion)
+	if len(typed) > 0 {
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(typed); err != nil {
+			return fmt.Errorf("failed to encode defaults: %w", err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write defaults: %w", err)
+	}
+	return nil
+}
+
+// SetDefault checks value and stores it for key in the user defaults file
+func SetDefault(key, value string) error {
+	s, err := findSetting(key)
+	if err != nil {
+		return err
+	}
+	if err := s.set(DefaultConfig(), value); err != nil {
+		return err
+	}
+	values, err := LoadDefaults()
+	if err != nil {
+		return err
+	}
+	values[key] = value
+	return SaveDefaults(values)
+}
+
+// UnsetDefault removes key from the user defaults file
+func UnsetDefault(key string) error {
+	if _, err := findSetting(key); err != nil {
+		return err
+	}
+	values, err := LoadDefaults()
+	if err != nil {
+		return err
+	}
+	delete(values, key)
+	return SaveDefaults(values)
+}
+
+// Defaults returns the effective default of every key, in the order of the
+// defaults file keys
+func Defaults() ([]Default, error) {
+	values, err := LoadDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	builtin := DefaultConfig()
+	var defaults []Default
+	for i := range settings {
+		s := &settings[i]
+		d := Default{Key: s.key, Value: s.get(builtin), Source: SourceBuiltin}
+		if s.key == "standard" {
+			d.Value = "" // follows the language
+		}
+		if value, ok := values[s.key]; ok {
+			d.Value, d.Source = value, SourceFile
+		}
+		if value, ok := os.LookupEnv(s.envName()); ok {
+			d.Value, d.Source = value, SourceEnv
+		}
+		defaults = append(defaults, d)
+	}
+	return defaults, nil
+}
+
+// GetDefault returns the effective default of key
+func GetDefault(key string) (Default, error) {
+	if _, err := findSetting(key); err != nil {
+		return Default{}, err
+	}
+	defaults, err := Defaults()
+	if err != nil {
+		return Default{}, err
+	}
+	for _, d := range defaults {
+		if d.Key == key {
+			return d, nil
+		}
+	}
+	return Default{}, nil
+}
+
+// UserDefaults returns DefaultConfig with the user defaults file and the
+// CPPINIT_* environment variables applied, in that order. The standard is
+// left empty unless one of them sets it, so that it follows the language.
+func UserDefaults() (*Config, error) {
+	defaults, err := Defaults()
+	if err != nil {
+		return nil, err
+	}
+
+	config := DefaultConfig()
+	config.Standard = ""
+	for _, d := range defaults {
+		if d.Source == SourceBuiltin {
+			continue
+		}
+		s, _ := findSetting(d.Key)
+		if err := s.set(config, d.Value); err != nil {
+			if d.Source == SourceEnv {
+				return nil, fmt.Errorf("%s: %w", s.envName(), err)
+			}
+			return nil, err
+		}
+	}
+	return config, nil
+}
+
+// DefaultKeys returns the keys of the defaults file in sorted order
+func DefaultKeys() []string {
+	keys := make([]string, 0, len(settings))
+	for _, s := range settings {
+		keys = append(keys, s.key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// PrintDefaults lists the effective defaults and where they come from
+func PrintDefaults(defaults []Default) {
+	path, _ := DefaultsPath()
+	fmt.Println(titleStyle.Render("Defaults"))
+	fmt.Println(dimStyle.Render("  file: " + path))
+	for _, d := range defaults {
+		value := d.Value
+		if value == "" {
+			value = dimStyle.Render("(unset)")
+		}
+		source := ""
+		switch d.Source {
+		case SourceFile:
+			source = dimStyle.Render("  (" + DefaultsFile + ")")
+		case SourceEnv:
+			source = dimStyle.Render(fmt.Sprintf("  ($%s%s)", EnvPrefix, strings.ToUpper(d.Key)))
+		}
+		fmt.Printf("  %-16s %s%s\n", d.Key, value, source)
+	}
+}
@@ -0,0 +1,137 @@
+package scaffold
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change
+const diffContext = 3
+
+// diffOp is a single line of an edit script
+type diffOp struct {
+	kind byte // ' ' unchanged, '-' removed, '+' added
+	line string
+	a, b int // line index in a and b (the side that does not apply is -1)
+}
+
+// splitLines splits text into lines without their trailing newlines
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffLines computes a line-based edit script from a to b using the longest
+// common subsequence
+func diffLines(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	ops := make([]diffOp, 0, n+m)
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && a[i] == b[j]:
+			ops = append(ops, diffOp{kind: ' ', line: a[i], a: i, b: j})
+			i++
+			j++
+		case j < m && (i == n || lcs[i][j+1] > lcs[i+1][j]):
+			ops = append(ops, diffOp{kind: '+', line: b[j], a: -1, b: j})
+			j++
+		default:
+			ops = append(ops, diffOp{kind: '-', line: a[i], a: i, b: -1})
+			i++
+		}
+	}
+	return ops
+}
+
+// UnifiedDiff returns a unified diff turning a into b, or an empty string if
+// both are equal
+func UnifiedDiff(aName, bName, a, b string) string {
+	if a == b {
+		return ""
+	}
+
+	ops := diffLines(splitLines(a), splitLines(b))
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("--- %s\n+++ %s\n", aName, bName))
+
+	for start := 0; start < len(ops); {
+		// Find the next change
+		for start < len(ops) && ops[start].kind == ' ' {
+			start++
+		}
+		if start == len(ops) {
+			break
+		}
+
+		// Extend the hunk until a run of unchanged lines is long enough to
+		// separate it from the next change
+		end := start
+		for end < len(ops) {
+			if ops[end].kind != ' ' {
+				end++
+				continue
+			}
+			run := end
+			for run < len(ops) && ops[run].kind == ' ' {
+				run++
+			}
+			if run == len(ops) || run-end > 2*diffContext {
+				break
+			}
+			end = run
+		}
+
+		from := max(start-diffContext, 0)
+		to := min(end+diffContext, len(ops))
+		writeHunk(&sb, ops[from:to])
+		start = to
+	}
+
+	return sb.String()
+}
+
+// writeHunk writes a hunk header followed by its lines
+func writeHunk(sb *strings.Builder, ops []diffOp) {
+	aStart, bStart, aLen, bLen := -1, -1, 0, 0
+	for _, op := range ops {
+		if op.a >= 0 {
+			if aStart < 0 {
+				aStart = op.a
+			}
+			aLen++
+		}
+		if op.b >= 0 {
+			if bStart < 0 {
+				bStart = op.b
+			}
+			bLen++
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", aStart+1, aLen, bStart+1, bLen))
+	for _, op := range ops {
+		sb.WriteByte(op.kind)
+		sb.WriteString(op.line)
+		sb.WriteByte('\n')
+	}
+}
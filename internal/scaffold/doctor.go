@@ -0,0 +1,375 @@
+package scaffold
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CheckStatus is the outcome of one doctor check
+type CheckStatus string
+
+const (
+	CheckPass CheckStatus = "pass"
+	CheckWarn CheckStatus = "warn" // optional tool missing or too old
+	CheckFail CheckStatus = "fail" // the project will not configure or build
+)
+
+// CheckResult is the outcome of looking for one tool
+type CheckResult struct {
+	Tool    string      `json:"tool"`
+	Path    string      `json:"path,omitempty"`    // executable that was found, empty if none
+	Version string      `json:"version,omitempty"` // parsed version, empty if unknown
+	Status  CheckStatus `json:"status"`
+	Detail  string      `json:"detail"`         // what was required and why
+	Hint    string      `json:"hint,omitempty"` // how to install or upgrade, empty when passing
+}
+
+// MissingToolsError reports required tools that are missing or too old
+type MissingToolsError struct {
+	Count int
+}
+
+func (e *MissingToolsError) Error() string {
+	return fmt.Sprintf("%d required tool(s) missing or too old", e.Count)
+}
+
+// toolCheck describes a tool a project needs
+type toolCheck struct {
+	name     string
+	commands []string // candidates, first found wins (compilers take the best)
+	min      string   // minimum version, empty for any
+	required bool
+	compiler bool // min depends on the compiler family and standard
+	reason   string
+	hints    map[string]string // install hint by GOOS, "" is the fallback
+}
+
+// minCMake is the oldest CMake that reads the generated CMakePresets.json,
+// which uses preset schema version 6
+const minCMake = "3.25"
+
+// Minimum compiler versions by standard: GCC first, then Clang
+var (
+	cppCompilerMin = map[string][2]string{
+		"11": {"4.8.1", "3.3"},
+		"14": {"5", "3.4"},
+		"17": {"7", "5"},
+		"20": {"10", "10"},
+		"23": {"11", "12"},
+	}
+	cCompilerMin = map[string][2]string{
+		"89": {"3", "3"},
+		"99": {"4.3", "3"},
+		"11": {"4.7", "3.1"},
+		"17": {"8", "6"},
+		"23": {"9", "9"},
+	}
+)
+
+// versionPattern matches the first dotted version number in --version output
+var versionPattern = regexp.MustCompile(`\d+\.\d+(\.\d+)?`)
+
+// installHints returns per-platform install hints for a package
+func installHints(apt, brew, fallback string) map[string]string {
+	return map[string]string{
+		"linux":  "sudo apt install " + apt,
+		"darwin": "brew install " + brew,
+		"":       fallback,
+	}
+}
+
+// doctorChecks lists the tools the project described by config needs
+func doctorChecks(config *Config) []toolCheck {
+	checks := []toolCheck{
+		{
+			name:     "cmake",
+			commands: []string{"cmake"},
+			min:      minCMake,
+			required: true,
+			reason:   "required to configure the project, CMakePresets.json uses schema v6",
+			hints:    installHints("cmake (or pip install cmake for a newer one)", "cmake", "https://cmake.org/download/"),
+		},
+		compilerCheck(config),
+		{
+			name:     "ninja",
+			commands: []string{"ninja", "ninja-build"},
+			reason:   "used by the CI workflow and Dockerfile",
+			hints:    installHints("ninja-build", "ninja", "https://github.com/ninja-build/ninja/releases"),
+		},
+		{
+			name:     "git",
+			commands: []string{"git"},
+			reason:   "used for version control and dependency downloads",
+			hints:    installHints("git", "git", "https://git-scm.com/downloads"),
+		},
+	}
+
+	if config.UseClangFormat {
+		checks = append(checks, toolCheck{
+			name:     "clang-format",
+			commands: []string{"clang-format"},
+			reason:   "the project includes .clang-format",
+			hints:    installHints("clang-format", "clang-format", "https://releases.llvm.org/"),
+		})
+	}
+	if config.UseClangTidy {
+		checks = append(checks, toolCheck{
+			name:     "clang-tidy",
+			commands: []string{"clang-tidy"},
+			reason:   "the project includes .clang-tidy",
+			hints:    installHints("clang-tidy", "llvm", "https://releases.llvm.org/"),
+		})
+	}
+	if config.UseCoverage {
+		checks = append(checks, toolCheck{
+			name:     "lcov",
+			commands: []string{"lcov"},
+			reason:   "needed for coverage reports",
+			hints:    installHints("lcov", "lcov", "https://github.com/linux-test-project/lcov/releases"),
+		})
+	}
+	if config.UseDoxygen {
+		checks = append(checks, toolCheck{
+			name:     "doxygen",
+			commands: []string{"doxygen"},
+			reason:   "needed for the docs target",
+			hints:    installHints("doxygen graphviz", "doxygen graphviz", "https://www.doxygen.nl/download.html"),
+		})
+	}
+	if config.UseDocker {
+		checks = append(checks, toolCheck{
+			name:     "docker",
+			commands: []string{"docker", "podman"},
+			reason:   "needed for the Dockerfile and devcontainer",
+			hints:    installHints("docker.io", "--cask docker", "https://docs.docker.com/get-docker/"),
+		})
+	}
+	if config.UsePreCommit {
+		checks = append(checks, toolCheck{
+			name:     "pre-commit",
+			commands: []string{"pre-commit"},
+			reason:   "needed for the pre-commit hooks",
+			hints:    installHints("pre-commit", "pre-commit", "pip install pre-commit"),
+		})
+	}
+
+	switch config.PackageManager {
+	case "conan":
+		checks = append(checks, toolCheck{
+			name:     "conan",
+			commands: []string{"conan"},
+			min:      "2.0",
+			required: true,
+			reason:   "dependencies come from conanfile.txt",
+			hints:    map[string]string{"": "pip install conan"},
+		})
+	case "vcpkg":
+		commands := []string{"vcpkg"}
+		if root := os.Getenv("VCPKG_ROOT"); root != "" {
+			commands = append(commands, filepath.Join(root, "vcpkg"))
+		}
+		checks = append(checks, toolCheck{
+			name:     "vcpkg",
+			commands: commands,
+			required: true,
+			reason:   "dependencies come from vcpkg.json",
+			hints:    map[string]string{"": "git clone https://github.com/microsoft/vcpkg && ./vcpkg/bootstrap-vcpkg.sh, then set VCPKG_ROOT"},
+		})
+	}
+
+	return checks
+}
+
+// compilerCheck returns the compiler check for the project's language. The
+// minimum version is resolved per candidate since GCC and Clang differ.
+func compilerCheck(config *Config) toolCheck {
+	check := toolCheck{
+		name:     "c++ compiler",
+		commands: []string{"g++", "clang++", "c++"},
+		required: true,
+		compiler: true,
+		reason:   "the project uses C++" + config.Standard,
+		hints:    installHints("g++ (or clang)", "llvm (or xcode-select --install)", "install GCC, Clang or Visual Studio Build Tools"),
+	}
+	env := "CXX"
+	if config.IsC() {
+		check.name = "c compiler"
+		check.commands = []string{"gcc", "clang", "cc"}
+		check.reason = "the project uses C" + config.Standard
+		check.hints = installHints("gcc (or clang)", "llvm (or xcode-select --install)", "install GCC, Clang or Visual Studio Build Tools")
+		env = "CC"
+	}
+	if cc := os.Getenv(env); cc != "" {
+		check.commands = append([]string{cc}, check.commands...)
+	}
+	return check
+}
+
+// compilerMin returns the minimum version of the compiler that printed
+// versionOutput for the project's standard
+func compilerMin(config *Config, versionOutput string) string {
+	table := cppCompilerMin
+	if config.IsC() {
+		table = cCompilerMin
+	}
+	mins, ok := table[config.Standard]
+	if !ok {
+		return ""
+	}
+	if strings.Contains(strings.ToLower(versionOutput), "clang") {
+		return mins[1]
+	}
+	return mins[0]
+}
+
+// toolVersion runs "<path> --version" and returns its output
+func toolVersion(path string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	out, _ := exec.CommandContext(ctx, path, "--version").CombinedOutput()
+	return string(out)
+}
+
+// compareVersions compares dotted versions numerically
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// RunDoctor looks for every tool the project described by config needs and
+// checks its version
+func RunDoctor(config *Config) []CheckResult {
+	checks := doctorChecks(config)
+	results := make([]CheckResult, 0, len(checks))
+	for _, check := range checks {
+		results = append(results, runCheck(config, check))
+	}
+	return results
+}
+
+// runCheck evaluates one tool. For compilers every candidate is tried and
+// the first that is new enough wins.
+func runCheck(config *Config, check toolCheck) CheckResult {
+	missing := CheckWarn
+	if check.required {
+		missing = CheckFail
+	}
+	hint := check.hints[runtime.GOOS]
+	if hint == "" {
+		hint = check.hints[""]
+	}
+
+	var tooOld *CheckResult
+	for _, command := range check.commands {
+		path, err := exec.LookPath(command)
+		if err != nil {
+			continue
+		}
+		output := toolVersion(path)
+		found := CheckResult{Tool: check.name, Path: path, Version: versionPattern.FindString(output), Status: CheckPass}
+
+		min := check.min
+		if check.compiler {
+			min = compilerMin(config, output)
+		}
+		found.Detail = check.reason
+		if min != "" {
+			found.Detail = fmt.Sprintf("needs %s+, %s", min, check.reason)
+		}
+
+		if min == "" || found.Version == "" || compareVersions(found.Version, min) >= 0 {
+			return found
+		}
+		if tooOld == nil {
+			found.Status = missing
+			found.Detail = "too old, " + found.Detail
+			found.Hint = hint
+			tooOld = &found
+		}
+	}
+	if tooOld != nil {
+		return *tooOld
+	}
+
+	return CheckResult{
+		Tool:   check.name,
+		Status: missing,
+		Detail: "not found on PATH, " + check.reason,
+		Hint:   hint,
+	}
+}
+
+// PrintDoctor prints the doctor results as a table and returns the number of
+// failed checks
+func PrintDoctor(config *Config, results []CheckResult) int {
+	langLabel := "C++"
+	if config.IsC() {
+		langLabel = "C"
+	}
+	fmt.Println(titleStyle.Render(fmt.Sprintf("Toolchain check for a %s%s project", langLabel, config.Standard)))
+
+	width := 0
+	for _, r := range results {
+		width = max(width, len(r.Tool))
+	}
+
+	var failed, warned int
+	for _, r := range results {
+		label := fmt.Sprintf("%-4s", r.Status)
+		switch r.Status {
+		case CheckPass:
+			label = successStyle.Render(label)
+		case CheckWarn:
+			warned++
+			label = dimStyle.Render(label)
+		case CheckFail:
+			failed++
+			label = pathStyle.Render(label)
+		}
+
+		version := r.Version
+		if r.Path == "" {
+			version = "-"
+		} else if version == "" {
+			version = "?"
+		}
+		fmt.Printf("  %s  %-*s  %-8s  %s\n", label, width, r.Tool, version, r.Detail)
+		if r.Hint != "" {
+			fmt.Printf("  %s  %-*s  %s\n", strings.Repeat(" ", 4), width, "", dimStyle.Render("→ "+r.Hint))
+		}
+	}
+
+	fmt.Println()
+	switch {
+	case failed > 0:
+		// Reported by the caller as an error
+	case warned > 0:
+		fmt.Println(successStyle.Render(fmt.Sprintf("✓ Ready to build (%d optional tool(s) missing)", warned)))
+	default:
+		fmt.Println(successStyle.Render("✓ Ready to build"))
+	}
+	return failed
+}
@@ -0,0 +1,115 @@
+package scaffold
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// treeNode is a directory or file in the printed plan tree
+type treeNode struct {
+	name     string
+	size     int
+	isDir    bool
+	children map[string]*treeNode
+}
+
+func (n *treeNode) child(name string, isDir bool) *treeNode {
+	if n.children == nil {
+		n.children = make(map[string]*treeNode)
+	}
+	c, ok := n.children[name]
+	if !ok {
+		c = &treeNode{name: name, isDir: isDir}
+		n.children[name] = c
+	}
+	return c
+}
+
+// sortedChildren returns directories first, then files, each alphabetically
+func (n *treeNode) sortedChildren() []*treeNode {
+	children := make([]*treeNode, 0, len(n.children))
+	for _, c := range n.children {
+		children = append(children, c)
+	}
+	sort.Slice(children, func(i, j int) bool {
+		if children[i].isDir != children[j].isDir {
+			return children[i].isDir
+		}
+		return children[i].name < children[j].name
+	})
+	return children
+}
+
+// buildTree turns the flat plan into a directory tree
+func buildTree(plan *Plan) *treeNode {
+	root := &treeNode{isDir: true}
+
+	addDir := func(dir string) *treeNode {
+		node := root
+		for _, part := range strings.Split(dir, "/") {
+			if part == "" || part == "." {
+				continue
+			}
+			node = node.child(part, true)
+		}
+		return node
+	}
+
+	for _, dir := range plan.Dirs {
+		addDir(dir)
+	}
+	for filename, content := range plan.Files {
+		parent := addDir(path.Dir(filename))
+		parent.child(path.Base(filename), false).size = len(content)
+	}
+
+	return root
+}
+
+// FormatPlanTree renders the plan as a tree with byte sizes
+func FormatPlanTree(rootName string, plan *Plan) string {
+	var sb strings.Builder
+	sb.WriteString(rootName + "/\n")
+
+	var walk func(node *treeNode, prefix string)
+	walk = func(node *treeNode, prefix string) {
+		children := node.sortedChildren()
+		for i, c := range children {
+			branch, indent := "├── ", "│   "
+			if i == len(children)-1 {
+				branch, indent = "└── ", "    "
+			}
+			if c.isDir {
+				sb.WriteString(prefix + branch + c.name + "/\n")
+				walk(c, prefix+indent)
+			} else {
+				sb.WriteString(fmt.Sprintf("%s%s%s (%d B)\n", prefix, branch, c.name, c.size))
+			}
+		}
+	}
+	walk(buildTree(plan), "")
+
+	return sb.String()
+}
+
+// PrintPlan prints what Generate would create for the configuration
+func PrintPlan(config *Config, plan *Plan) {
+	fmt.Println()
+	fmt.Println(titleStyle.Render("Dry run: nothing was written"))
+	fmt.Print(FormatPlanTree(config.OutputDir, plan))
+	fmt.Println()
+	fmt.Println(dimStyle.Render(fmt.Sprintf("%d files, %d bytes", len(plan.Files), plan.Size())))
+}
+
+// PrintPlanFile prints the planned content of a single file
+func PrintPlanFile(plan *Plan, filename string) error {
+	filename = path.Clean(strings.ReplaceAll(filename, "\\", "/"))
+	content, ok := plan.Files[filename]
+	if !ok {
+		return fmt.Errorf("%s is not part of the generated project", filename)
+	}
+	fmt.Print(content)
+	return nil
+}
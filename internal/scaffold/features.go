@@ -0,0 +1,616 @@
+package scaffold
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/nikitalobanov12/cppinit/internal/templates"
+)
+
+// Snippets of the root CMakeLists.txt that features own. The generated
+// file is composed from them, and add and remove look for them.
+const (
+	sanitizersInclude     = "include(Sanitizers)"
+	coverageInclude       = "include(Coverage)"
+	staticAnalysisInclude = "include(StaticAnalysis)"
+	doxygenInclude        = "include(Doxygen)"
+
+	warningsCall = `# Apply compiler warnings
+set_project_warnings(${PROJECT_NAME})`
+
+	sanitizersCall = `# Apply sanitizers (if enabled)
+enable_sanitizers(${PROJECT_NAME})`
+
+	coverageCall = `# Apply code coverage (if enabled)
+enable_coverage(${PROJECT_NAME})`
+
+	staticAnalysisCall = `# Apply static analysis (if enabled)
+enable_static_analysis(${PROJECT_NAME})`
+
+	testsBlock = `# Testing
+option(BUILD_TESTS "Build the tests" ON)
+if(BUILD_TESTS)
+    enable_testing()
+    add_subdirectory(tests)
+endif()`
+
+	benchmarksBlock = `# Benchmarks
+option(BUILD_BENCHMARKS "Build the benchmarks" OFF)
+if(BUILD_BENCHMARKS)
+    add_subdirectory(benchmarks)
+endif()`
+
+	doxygenBlock = `# Documentation
+enable_docs()`
+
+	coverageBlock = `# Coverage report target
+add_coverage_target()`
+
+	// installMarker starts the install rules of library projects; blocks
+	// are added in front of it
+	installMarker = "# Installation rules\n"
+)
+
+// Groups of features in the flag help and the wizard
+const (
+	GroupDependencies = "Dependencies"
+	GroupQuality      = "Code Quality"
+	GroupDevOps       = "DevOps & Tooling"
+)
+
+// Feature is an optional module of a project. It declares everything the
+// generator composes for it, and can be added to or removed from an existing
+// project.
+type Feature struct {
+	Name        string
+	Description string
+
+	// Field is the config switch of the feature. Features switched some
+	// other way, such as tests by their framework, set enabled and set.
+	Field   func(c *Config) *bool
+	enabled func(c *Config) bool
+	set     func(c *Config, on bool)
+
+	// Supported reports whether the feature fits the project; nil means
+	// always. Unsupported features are not generated or asked for.
+	Supported func(c *Config) bool
+
+	// Group places the flag of a feature with a Field in the help and its
+	// question in the wizard; Prompt and Hint are the question
+	Group  string
+	Prompt string
+	Hint   string
+
+	// Dirs and Files are created for the feature; Files maps output paths
+	// to template names
+	Dirs  []string
+	Files func(c *Config) map[string]string
+
+	// Root CMakeLists.txt snippets owned by the feature: an include() of its
+	// module, a call applied to the project target and a top-level block
+	Include string
+	Call    string
+	Block   string
+
+	// Presets are added to CMakePresets.json
+	Presets []CMakePreset
+
+	// Summary describes the feature in the README and the success message,
+	// Readme and CIJob name the templates of its README section and its
+	// GitHub Actions job, and NextSteps are printed after generation
+	Summary   func(c *Config) string
+	Readme    string
+	CIJob     string
+	NextSteps func(c *Config) []NextStep
+}
+
+// CMakePreset is a configure preset that inherits debug and switches on one
+// cache variable, optionally with a build preset of the same name
+type CMakePreset struct {
+	Name        string
+	DisplayName string
+	Variable    string
+	Build       bool
+}
+
+// Enabled reports whether the feature is on
+func (f *Feature) Enabled(c *Config) bool {
+	if f.Field != nil {
+		return *f.Field(c)
+	}
+	return f.enabled(c)
+}
+
+// Set switches the feature
+func (f *Feature) Set(c *Config, on bool) {
+	if f.Field != nil {
+		*f.Field(c) = on
+		return
+	}
+	f.set(c, on)
+}
+
+// Active reports whether the feature is on and supported by the project
+func (f *Feature) Active(c *Config) bool {
+	return f.Enabled(c) && (f.Supported == nil || f.Supported(c))
+}
+
+// Key returns the config key of the feature, e.g. clang_format
+func (f *Feature) Key() string {
+	return strings.ReplaceAll(f.Name, "-", "_")
+}
+
+// summary returns a Summary that does not depend on the config
+func summary(s string) func(c *Config) string {
+	return func(*Config) string { return s }
+}
+
+// files returns a Files that does not depend on the config
+func files(paths ...string) func(c *Config) map[string]string {
+	return func(*Config) map[string]string {
+		m := make(map[string]string, len(paths))
+		for _, path := range paths {
+			m[path] = path + ".tmpl"
+		}
+		return m
+	}
+}
+
+// Features lists the optional modules of a project, in the order their
+// snippets, presets, README sections and CI jobs appear
+var Features = []Feature{
+	{
+		Name:        "tests",
+		Description: "Unit tests (GoogleTest, Catch2, doctest or Unity)",
+		enabled:     func(c *Config) bool { return c.TestFramework != "none" },
+		set: func(c *Config, on bool) {
+			switch {
+			case !on:
+				c.TestFramework = "none"
+			case c.TestFramework != "none":
+				// keep the framework that was asked for
+			case c.IsC():
+				c.TestFramework = "unity"
+			default:
+				c.TestFramework = "googletest"
+			}
+		},
+		Dirs: []string{"tests"},
+		Files: func(c *Config) map[string]string {
+			main := "tests/test_main.cpp"
+			if c.IsC() {
+				main = "tests/test_main.c"
+			}
+			return map[string]string{"tests/CMakeLists.txt": "tests/CMakeLists.txt.tmpl", main: main + ".tmpl"}
+		},
+		Block:   testsBlock,
+		Summary: func(c *Config) string { return c.TestFramework + " testing framework" },
+		Readme:  "features/tests/README.md.tmpl",
+		CIJob:   "features/tests/ci.yml.tmpl",
+		NextSteps: func(c *Config) []NextStep {
+			return []NextStep{{"Run tests", []string{"ctest --preset debug"}}}
+		},
+	},
+	{
+		Name:        "benchmark",
+		Description: "Google Benchmark for library projects",
+		Field:       func(c *Config) *bool { return &c.IncludeBenchmark },
+		Supported:   func(c *Config) bool { return c.IsCpp() && c.ProjectType != "executable" },
+		Group:       GroupDependencies,
+		Prompt:      "Include benchmarks?",
+		Hint:        "Add Google Benchmark for performance testing",
+		Dirs:        []string{"benchmarks"},
+		Files:       files("benchmarks/CMakeLists.txt", "benchmarks/benchmark_main.cpp"),
+		Block:       benchmarksBlock,
+		Summary:     summary("Google Benchmark for performance testing"),
+	},
+	{
+		Name:        "clang-format",
+		Description: "clang-format config",
+		Field:       func(c *Config) *bool { return &c.UseClangFormat },
+		Group:       GroupQuality,
+		Prompt:      "clang-format (code formatting)",
+		Files:       files(".clang-format"),
+		Summary:     summary("clang-format for code formatting"),
+	},
+	{
+		Name:        "sanitizers",
+		Description: "Address, UB, Thread and Memory sanitizers with presets",
+		Field:       func(c *Config) *bool { return &c.UseSanitizers },
+		Group:       GroupQuality,
+		Prompt:      "Sanitizers (ASan, UBSan, TSan)",
+		Files:       files("cmake/Sanitizers.cmake"),
+		Include:     sanitizersInclude,
+		Call:        sanitizersCall,
+		Presets: []CMakePreset{
+			{"asan", "AddressSanitizer", "ENABLE_SANITIZER_ADDRESS", true},
+			{"ubsan", "UndefinedBehaviorSanitizer", "ENABLE_SANITIZER_UNDEFINED", true},
+			{"tsan", "ThreadSanitizer", "ENABLE_SANITIZER_THREAD", true},
+			{"msan", "MemorySanitizer (Clang only)", "ENABLE_SANITIZER_MEMORY", false},
+		},
+		Summary: summary("Address, UB, and Thread sanitizers"),
+		Readme:  "features/sanitizers/README.md.tmpl",
+		CIJob:   "features/sanitizers/ci.yml.tmpl",
+		NextSteps: func(c *Config) []NextStep {
+			return []NextStep{{"Run with sanitizers", []string{"cmake --preset asan && cmake --build --preset asan"}}}
+		},
+	},
+	{
+		Name:        "coverage",
+		Description: "Code coverage with a coverage preset and report target",
+		Field:       func(c *Config) *bool { return &c.UseCoverage },
+		Group:       GroupQuality,
+		Prompt:      "Code coverage (gcov/lcov)",
+		Files:       files("cmake/Coverage.cmake"),
+		Include:     coverageInclude,
+		Call:        coverageCall,
+		Block:       coverageBlock,
+		Presets:     []CMakePreset{{"coverage", "Code Coverage", "ENABLE_COVERAGE", true}},
+		Summary:     summary("Code coverage support"),
+		Readme:      "features/coverage/README.md.tmpl",
+		CIJob:       "features/coverage/ci.yml.tmpl",
+	},
+	{
+		Name:        "clang-tidy",
+		Description: "clang-tidy config and static analysis during builds",
+		Field:       func(c *Config) *bool { return &c.UseClangTidy },
+		Group:       GroupQuality,
+		Prompt:      "clang-tidy (static analysis)",
+		Files:       files(".clang-tidy", "cmake/StaticAnalysis.cmake"),
+		Include:     staticAnalysisInclude,
+		Call:        staticAnalysisCall,
+		Summary:     summary("clang-tidy for static analysis"),
+	},
+	{
+		Name:        "doxygen",
+		Description: "Doxygen documentation target",
+		Field:       func(c *Config) *bool { return &c.UseDoxygen },
+		Group:       GroupQuality,
+		Prompt:      "Doxygen (documentation)",
+		Files:       files("cmake/Doxygen.cmake"),
+		Include:     doxygenInclude,
+		Block:       doxygenBlock,
+		Summary:     summary("Doxygen API documentation"),
+	},
+	{
+		Name:        "precommit",
+		Description: "pre-commit hooks",
+		Field:       func(c *Config) *bool { return &c.UsePreCommit },
+		Group:       GroupQuality,
+		Prompt:      "pre-commit hooks",
+		Files:       files(".pre-commit-config.yaml"),
+		Summary:     summary("pre-commit hooks"),
+		NextSteps: func(c *Config) []NextStep {
+			return []NextStep{{"Setup pre-commit hooks", []string{"pip install pre-commit && pre-commit install"}}}
+		},
+	},
+	{
+		Name:        "docker",
+		Description: "Dockerfile and VS Code devcontainer",
+		Field:       func(c *Config) *bool { return &c.UseDocker },
+		Group:       GroupDevOps,
+		Prompt:      "Include Docker support?",
+		Hint:        "Dockerfile and devcontainer for VS Code",
+		Dirs:        []string{".devcontainer"},
+		Files: func(c *Config) map[string]string {
+			m := files(".dockerignore", ".devcontainer/devcontainer.json")(c)
+			if c.ProjectType == "executable" {
+				m["Dockerfile"] = "Dockerfile.tmpl"
+			}
+			return m
+		},
+		Summary: summary("Docker image and devcontainer"),
+		Readme:  "features/docker/README.md.tmpl",
+		NextSteps: func(c *Config) []NextStep {
+			return []NextStep{{"Or use Docker", []string{"docker build -t " + templates.ShellQuote(c.ProjectName) + " ."}}}
+		},
+	},
+	{
+		Name:        "ci",
+		Description: "GitHub Actions CI and Dependabot",
+		Field:       func(c *Config) *bool { return &c.IncludeCI },
+		Group:       GroupDevOps,
+		Prompt:      "Include GitHub Actions CI?",
+		Hint:        "Automated builds, tests, and linting",
+		Dirs:        []string{".github/workflows"},
+		Files:       files(".github/workflows/ci.yml", ".github/dependabot.yml"),
+		Summary:     summary("GitHub Actions CI/CD"),
+	},
+	{
+		Name:        "vscode",
+		Description: "VSCode settings, launch configs, tasks and extensions",
+		Field:       func(c *Config) *bool { return &c.IncludeVSCode },
+		Group:       GroupDevOps,
+		Prompt:      "Include VSCode configuration?",
+		Hint:        "Settings, launch configs, and recommended extensions",
+		Dirs:        []string{".vscode"},
+		Files: files(".vscode/settings.json", ".vscode/extensions.json",
+			".vscode/launch.json", ".vscode/tasks.json"),
+		Summary: summary("VS Code settings, launch configs and tasks"),
+	},
+}
+
+// FeatureNames returns the names of all features
+func FeatureNames() []string {
+	names := make([]string, 0, len(Features))
+	for _, f := range Features {
+		names = append(names, f.Name)
+	}
+	return names
+}
+
+// LookupFeature finds a feature by name
+func LookupFeature(name string) (*Feature, error) {
+	for i := range Features {
+		if Features[i].Name == name {
+			return &Features[i], nil
+		}
+	}
+	return nil, fmt.Errorf("unknown feature %q (%s)", name, suggest(name, FeatureNames()))
+}
+
+// ActiveFeatures returns the features generated for c
+func ActiveFeatures(c *Config) []*Feature {
+	var active []*Feature
+	for i := range Features {
+		if Features[i].Active(c) {
+			active = append(active, &Features[i])
+		}
+	}
+	return active
+}
+
+// blockOrder lists the features with a Block in the order their blocks
+// appear in the root CMakeLists.txt, which differs from the feature order:
+// the documentation block comes before the coverage report target
+var blockOrder = []string{"tests", "benchmark", "doxygen", "coverage"}
+
+// blockRank returns the position of the feature's block in blockOrder;
+// unlisted features come last
+func blockRank(f *Feature) int {
+	if i := slices.Index(blockOrder, f.Name); i >= 0 {
+		return i
+	}
+	return len(blockOrder)
+}
+
+// orderBlocks returns the features of list that have a Block, in block
+// order
+func orderBlocks(list []*Feature) []*Feature {
+	var blocks []*Feature
+	for _, f := range list {
+		if f.Block != "" {
+			blocks = append(blocks, f)
+		}
+	}
+	sort.SliceStable(blocks, func(i, j int) bool { return blockRank(blocks[i]) < blockRank(blocks[j]) })
+	return blocks
+}
+
+// moduleIncludes are the include() lines of modules shipped in cmake/
+var moduleIncludes = func() []string {
+	includes := []string{"include(CompilerWarnings)"}
+	for _, f := range Features {
+		if f.Include != "" {
+			includes = append(includes, f.Include)
+		}
+	}
+	return append(includes, "include(CPM)")
+}()
+
+// targetCalls are the calls applied to the project target
+var targetCalls = func() []string {
+	calls := []string{keyLine(warningsCall)}
+	for _, f := range Features {
+		if f.Call != "" {
+			calls = append(calls, keyLine(f.Call))
+		}
+	}
+	return calls
+}()
+
+// snippetLines splits a snippet into its lines
+func snippetLines(snippet string) []string {
+	return strings.Split(snippet, "\n")
+}
+
+// lastLineIndex returns the index of the last line equal (ignoring
+// surrounding whitespace) to one of candidates, or -1
+func lastLineIndex(lines []string, candidates []string) int {
+	last := -1
+	for i, line := range lines {
+		for _, c := range candidates {
+			if strings.TrimSpace(line) == c {
+				last = i
+			}
+		}
+	}
+	return last
+}
+
+// keyLine returns the first line of a snippet that is not a comment, which
+// identifies the snippet in a file
+func keyLine(snippet string) string {
+	for _, line := range snippetLines(snippet) {
+		if !strings.HasPrefix(line, "#") {
+			return line
+		}
+	}
+	return snippet
+}
+
+// containsSnippet reports whether the snippet's key line is already present
+func containsSnippet(lines []string, snippet string) bool {
+	return lastLineIndex(lines, []string{keyLine(snippet)}) >= 0
+}
+
+// insertLines inserts lines at index at
+func insertLines(lines []string, at int, insert ...string) []string {
+	out := make([]string, 0, len(lines)+len(insert))
+	out = append(out, lines[:at]...)
+	out = append(out, insert...)
+	return append(out, lines[at:]...)
+}
+
+// firstLaterSnippet returns the index of the first line of the earliest
+// snippet that a feature following f in Features has in lines, or -1
+func firstLaterSnippet(lines []string, f *Feature, snippet func(*Feature) string) int {
+	at := -1
+	later := false
+	for i := range Features {
+		other := &Features[i]
+		if other.Name == f.Name {
+			later = true
+			continue
+		}
+		if !later || snippet(other) == "" {
+			continue
+		}
+		if j := findSnippet(lines, snippet(other)); j >= 0 && (at < 0 || j < at) {
+			at = j
+		}
+	}
+	return at
+}
+
+// lastSnippetEnd returns the index of the last line of the blocks ranked
+// before f's, or else of the last target call, or -1
+func lastSnippetEnd(lines []string, f *Feature) int {
+	end := -1
+	for i := range Features {
+		earlier := &Features[i]
+		if earlier.Block == "" || blockRank(earlier) >= blockRank(f) {
+			continue
+		}
+		if j := findSnippet(lines, earlier.Block); j >= 0 {
+			end = max(end, j+len(snippetLines(earlier.Block))-1)
+		}
+	}
+	if end < 0 {
+		end = lastLineIndex(lines, targetCalls)
+	}
+	return end
+}
+
+// addCMakeSnippets inserts a feature's include, call and block into the
+// root CMakeLists.txt where a fresh render puts them, so that later
+// upgrades see the same lines in the same place. It fails when the place a
+// snippet belongs cannot be found, e.g. because the file was restructured
+// by hand.
+func addCMakeSnippets(content string, f *Feature) (string, error) {
+	lines := strings.Split(content, "\n")
+
+	if f.Include != "" && !containsSnippet(lines, f.Include) {
+		// In front of the includes of later features and CPM
+		at := firstLaterSnippet(lines, f, func(other *Feature) string { return other.Include })
+		if cpm := lastLineIndex(lines, []string{"include(CPM)"}); cpm >= 0 && (at < 0 || cpm < at) {
+			at = cpm
+		}
+		if at < 0 {
+			if at = lastLineIndex(lines, moduleIncludes); at < 0 {
+				return "", fmt.Errorf("cannot find where to add %s: CMakeLists.txt has no include(CompilerWarnings) line", f.Include)
+			}
+			at++
+		}
+		lines = insertLines(lines, at, f.Include)
+	}
+
+	if f.Call != "" && !containsSnippet(lines, f.Call) {
+		// In front of the calls of later features, each followed by a blank line
+		if at := firstLaterSnippet(lines, f, func(other *Feature) string { return other.Call }); at >= 0 {
+			lines = insertLines(lines, at, append(snippetLines(f.Call), "")...)
+		} else {
+			at := lastLineIndex(lines, targetCalls)
+			if at < 0 {
+				return "", fmt.Errorf("cannot find where to add the %s call: CMakeLists.txt has no set_project_warnings(${PROJECT_NAME}) line", f.Name)
+			}
+			lines = insertLines(lines, at+1, append([]string{""}, snippetLines(f.Call)...)...)
+		}
+	}
+
+	if f.Block != "" && !containsSnippet(lines, f.Block) {
+		block := append(snippetLines(f.Block), "")
+		// In front of the install rules or the first block that follows
+		at := lastLineIndex(lines, []string{strings.TrimSpace(installMarker)})
+		for i := range Features {
+			later := &Features[i]
+			if later.Block == "" || blockRank(later) <= blockRank(f) {
+				continue
+			}
+			if j := findSnippet(lines, later.Block); j >= 0 && (at < 0 || j < at) {
+				at = j
+			}
+		}
+		if at >= 0 {
+			lines = insertLines(lines, at, block...)
+		} else if after := lastSnippetEnd(lines, f); after >= 0 {
+			// Behind the earlier blocks or the target calls
+			lines = insertLines(lines, after+1, append([]string{""}, snippetLines(f.Block)...)...)
+		} else {
+			// Append at the end, separated by a blank line
+			for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+				lines = lines[:len(lines)-1]
+			}
+			lines = append(append(lines, ""), block...)
+		}
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// findSnippet returns the index of the first run of lines matching the
+// snippet (ignoring surrounding whitespace), or -1
+func findSnippet(lines []string, snippet string) int {
+	want := snippetLines(snippet)
+	for i := 0; i+len(want) <= len(lines); i++ {
+		match := true
+		for j, w := range want {
+			if strings.TrimSpace(lines[i+j]) != strings.TrimSpace(w) {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i
+		}
+	}
+	return -1
+}
+
+// isBlank reports whether lines[i] exists and is empty
+func isBlank(lines []string, i int) bool {
+	return i >= 0 && i < len(lines) && strings.TrimSpace(lines[i]) == ""
+}
+
+// removeSnippet deletes a snippet and, when it stood between two blank
+// lines, one of them. It reports false when the snippet's key
+// line is still present afterwards, i.e. the snippet was edited by hand.
+func removeSnippet(lines []string, snippet string) ([]string, bool) {
+	if snippet == "" {
+		return lines, true
+	}
+	if at := findSnippet(lines, snippet); at >= 0 {
+		end := at + len(snippetLines(snippet))
+		if isBlank(lines, at-1) && isBlank(lines, end) {
+			end++
+		}
+		lines = append(lines[:at:at], lines[end:]...)
+	}
+	return lines, !containsSnippet(lines, snippet)
+}
+
+// removeCMakeSnippets deletes a feature's include, call and block from the
+// root CMakeLists.txt. Snippets that were edited by hand are left in place
+// and reported by returning false.
+func removeCMakeSnippets(content string, f *Feature) (string, bool) {
+	lines := strings.Split(content, "\n")
+	clean := true
+	for _, snippet := range []string{f.Include, f.Call, f.Block} {
+		var ok bool
+		lines, ok = removeSnippet(lines, snippet)
+		clean = clean && ok
+	}
+	return strings.Join(lines, "\n"), clean
+}
@@ -4,576 +4,301 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/nikitalobanov12/cppinit/internal/templates"
 )
 
-// Generate creates the project structure based on the configuration
-func Generate(config *Config) error {
-	// Create base directory
-	if err := os.MkdirAll(config.OutputDir, 0755); err != nil {
-		return fmt.Errorf("failed to create project directory: %w", err)
+// Plan describes the directories and files a project consists of,
+// with paths relative to the project's output directory
+type Plan struct {
+	Dirs  []string
+	Files map[string]string
+}
+
+// Paths returns the planned file paths in sorted order
+func (p *Plan) Paths() []string {
+	paths := make([]string, 0, len(p.Files))
+	for path := range p.Files {
+		paths = append(paths, path)
 	}
+	sort.Strings(paths)
+	return paths
+}
 
-	// Create directory structure
-	dirs := []string{
-		"src",
-		"include/" + config.ProjectName,
-		"cmake",
+// Size returns the total number of bytes across all planned files
+func (p *Plan) Size() int {
+	total := 0
+	for _, content := range p.Files {
+		total += len(content)
+	}
+	return total
+}
+
+// Generate creates the project structure based on the configuration.
+// Existing files are handled according to opts.Conflict.
+func Generate(config *Config, opts Options) (*Result, error) {
+	plan, err := BuildPlan(config, opts.Templates)
+	if err != nil {
+		return nil, err
+	}
+	skipped, err := ResolveConflicts(config.OutputDir, plan, opts)
+	if err != nil {
+		return nil, err
+	}
+	// Only files that are written belong in the record, which is always
+	// written itself
+	plan.AddManifest(config, opts.Version)
+
+	if err := WritePlan(config.OutputDir, plan); err != nil {
+		return nil, err
 	}
 
-	if config.TestFramework != "none" {
-		dirs = append(dirs, "tests")
+	sizes := make(map[string]int, len(plan.Files))
+	for filename, content := range plan.Files {
+		sizes[filename] = len(content)
 	}
+	return &Result{Written: plan.Paths(), Skipped: skipped, Sizes: sizes}, nil
+}
 
-	if config.IncludeBenchmark && config.ProjectType != "executable" {
-		dirs = append(dirs, "benchmarks")
+// writeFiles writes every directory and file of the plan below root
+func writeFiles(root string, plan *Plan) error {
+	// Create base directory
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return fmt.Errorf("failed to create project directory: %w", err)
 	}
+	return WriteFS(DirFS(root), plan)
+}
 
-	if config.IncludeVSCode {
-		dirs = append(dirs, ".vscode")
+// templateData is what templates are rendered against: every Config field
+// plus a few derived values
+type templateData struct {
+	*Config
+	Year          string // current year, for the license
+	SourceExt     string // ".c" or ".cpp"
+	LanguageLabel string // "C" or "C++"
+	LicenseName   string // e.g. "Apache 2.0"
+
+	// Pack holds the option values of the project's template pack
+	Pack map[string]any
+
+	// Composed from the active features, in feature order
+	CMakeIncludes    []string      // include() lines of the root CMakeLists.txt
+	CMakeCalls       []string      // calls applied to the project target
+	CMakeBlocks      []string      // top-level blocks, in block order
+	CMakeModules     []string      // file names of the included modules
+	CMakePresets     []CMakePreset // configure and build presets
+	FeatureSummaries []string      // README feature list
+	ReadmeSections   []string      // rendered README sections
+	CIJobs           []string      // rendered GitHub Actions jobs
+}
+
+// newTemplateData derives the template data for config
+func newTemplateData(config *Config) *templateData {
+	data := &templateData{
+		Config:        config,
+		Year:          time.Now().Format("2006"),
+		SourceExt:     ".cpp",
+		LanguageLabel: "C++",
+		LicenseName: map[string]string{
+			"mit":     "MIT",
+			"apache2": "Apache 2.0",
+			"gpl3":    "GPL 3.0",
+			"bsd3":    "BSD 3-Clause",
+		}[config.License],
 	}
+	if config.IsC() {
+		data.SourceExt = ".c"
+		data.LanguageLabel = "C"
+	}
+	return data
+}
 
-	if config.UseDocker {
-		dirs = append(dirs, ".devcontainer")
+// addFeatures composes the parts of the active features into the template
+// data, rendering their README sections and CI jobs
+func (r *renderer) addFeatures(features []*Feature) {
+	d := r.data
+	for _, f := range features {
+		if f.Include != "" {
+			d.CMakeIncludes = append(d.CMakeIncludes, f.Include)
+			module := strings.TrimSuffix(strings.TrimPrefix(f.Include, "include("), ")")
+			d.CMakeModules = append(d.CMakeModules, module+".cmake")
+		}
+		if f.Call != "" {
+			d.CMakeCalls = append(d.CMakeCalls, f.Call)
+		}
+		d.CMakePresets = append(d.CMakePresets, f.Presets...)
+		if f.Summary != nil {
+			d.FeatureSummaries = append(d.FeatureSummaries, f.Summary(d.Config))
+		}
+		if f.Readme != "" {
+			d.ReadmeSections = append(d.ReadmeSections, r.render(f.Readme))
+		}
+		if f.CIJob != "" {
+			d.CIJobs = append(d.CIJobs, r.render(f.CIJob))
+		}
+	}
+	for _, f := range orderBlocks(features) {
+		d.CMakeBlocks = append(d.CMakeBlocks, f.Block)
 	}
+}
+
+// renderer renders templates for one plan and keeps the first error
+type renderer struct {
+	set  *templates.Set
+	data *templateData
+	err  error
+}
 
-	if config.IncludeCI {
-		dirs = append(dirs, ".github/workflows")
+// render renders the named template, or returns "" after an error
+func (r *renderer) render(name string) string {
+	if r.err != nil {
+		return ""
+	}
+	content, err := r.set.Render(name, r.data)
+	if err != nil {
+		r.err = fmt.Errorf("failed to render %s: %w", name, err)
 	}
+	return content
+}
 
-	for _, dir := range dirs {
-		path := filepath.Join(config.OutputDir, dir)
-		if err := os.MkdirAll(path, 0755); err != nil {
-			return fmt.Errorf("failed to create directory %s: %w", dir, err)
+// BuildPlan renders every file for the configuration in memory without
+// touching disk. Templates are looked up in set, or only among the
+// built-in templates when set is nil.
+func BuildPlan(config *Config, set *templates.Set) (*Plan, error) {
+	if set == nil {
+		set = templates.New()
+	}
+	data := newTemplateData(config)
+
+	// A pack's templates rank below explicit overrides
+	var pack *Pack
+	if config.Pack != "" {
+		var err error
+		if pack, err = FindPack(config.Pack); err != nil {
+			return nil, err
 		}
+		if data.Pack, err = pack.ResolveOptions(config.PackOptions); err != nil {
+			return nil, err
+		}
+		set = set.WithFallback(filepath.Join(pack.Dir, "templates"))
+	}
+
+	r := &renderer{set: set, data: data}
+	features := ActiveFeatures(config)
+	r.addFeatures(features)
+
+	// Create directory structure
+	names := config.Names()
+	dirs := []string{"src"}
+	if !config.OwnHeaders {
+		dirs = append(dirs, "include/"+names.FileStem)
+	}
+	dirs = append(dirs, "cmake")
+	for _, f := range features {
+		dirs = append(dirs, f.Dirs...)
 	}
 
 	// Generate all files
 	files := make(map[string]string)
 
 	// Core CMake files
-	files["CMakeLists.txt"] = generateRootCMakeLists(config)
-	files["cmake/CompilerWarnings.cmake"] = templates.CompilerWarningsCMake()
+	files["CMakeLists.txt"] = r.render("CMakeLists.txt.tmpl")
+	files["cmake/CompilerWarnings.cmake"] = r.render("cmake/CompilerWarnings.cmake.tmpl")
 
 	// CMake presets
-	files["CMakePresets.json"] = templates.CMakePresets(
-		config.ProjectName,
-		config.PackageManager,
-		config.UseSanitizers,
-		config.UseCoverage,
-	)
-
-	// Additional CMake modules
-	if config.UseSanitizers {
-		files["cmake/Sanitizers.cmake"] = templates.SanitizersCMake()
-	}
-	if config.UseCoverage {
-		files["cmake/Coverage.cmake"] = templates.CoverageCMake()
-	}
-	if config.UseClangTidy {
-		files["cmake/StaticAnalysis.cmake"] = templates.StaticAnalysisCMake()
-	}
-	if config.UseDoxygen {
-		files["cmake/Doxygen.cmake"] = templates.DoxygenCMake()
-	}
+	files["CMakePresets.json"] = r.render("CMakePresets.json.tmpl")
+
 	if config.PackageManager == "cpm" {
-		files["cmake/CPM.cmake"] = templates.CPMCMake()
+		files["cmake/CPM.cmake"] = r.render("cmake/CPM.cmake.tmpl")
 	}
 
 	// Source files - use appropriate extensions for C or C++
+	header := "include/" + names.FileStem + "/" + names.FileStem
 	if config.IsC() {
 		// C source files
 		if config.ProjectType == "executable" {
-			files["src/main.c"] = templates.MainC(config.ProjectName)
+			files["src/main.c"] = r.render("src/main.c.tmpl")
 		} else if config.ProjectType == "static" {
-			files["src/"+config.ProjectName+".c"] = templates.LibraryC(config.ProjectName)
-			files["include/"+config.ProjectName+"/"+config.ProjectName+".h"] = templates.LibraryH(config.ProjectName)
+			files["src/"+names.FileStem+".c"] = r.render("src/library.c.tmpl")
+			files[header+".h"] = r.render("include/library.h.tmpl")
 		}
 	} else {
 		// C++ source files
 		if config.ProjectType == "executable" {
-			files["src/main.cpp"] = templates.MainCpp(config.ProjectName)
+			files["src/main.cpp"] = r.render("src/main.cpp.tmpl")
 		} else if config.ProjectType == "static" {
-			files["src/"+config.ProjectName+".cpp"] = templates.LibraryCpp(config.ProjectName)
-			files["include/"+config.ProjectName+"/"+config.ProjectName+".hpp"] = templates.LibraryHpp(config.ProjectName)
+			files["src/"+names.FileStem+".cpp"] = r.render("src/library.cpp.tmpl")
+			files[header+".hpp"] = r.render("include/library.hpp.tmpl")
 		} else if config.ProjectType == "header-only" {
-			files["include/"+config.ProjectName+"/"+config.ProjectName+".hpp"] = templates.HeaderOnlyHpp(config.ProjectName)
+			files[header+".hpp"] = r.render("include/header-only.hpp.tmpl")
 		}
 	}
 
-	// Test files
-	if config.TestFramework != "none" {
-		files["tests/CMakeLists.txt"] = templates.TestsCMakeLists(config.ProjectName, config.ProjectType, config.TestFramework, config.IsC())
-		if config.IsC() {
-			files["tests/test_main.c"] = templates.TestMainC(config.ProjectName, config.ProjectType, config.TestFramework)
-		} else {
-			files["tests/test_main.cpp"] = templates.TestMainCpp(config.ProjectName, config.ProjectType, config.TestFramework)
+	// Code a project already had replaces the placeholders
+	if config.HasExistingCode() {
+		for filename := range files {
+			if strings.HasPrefix(filename, "include/") ||
+				len(config.Sources) > 0 && strings.HasPrefix(filename, "src/") {
+				delete(files, filename)
+			}
 		}
 	}
 
-	// Benchmark files
-	if config.IncludeBenchmark && config.ProjectType != "executable" {
-		files["benchmarks/CMakeLists.txt"] = templates.BenchmarkCMake(config.ProjectName)
-		files["benchmarks/benchmark_main.cpp"] = templates.BenchmarkMain(config.ProjectName, config.ProjectType)
+	// Feature files
+	for _, f := range features {
+		if f.Files == nil {
+			continue
+		}
+		for filename, name := range f.Files(config) {
+			files[filename] = r.render(name)
+		}
 	}
 
 	// Package manager files
 	switch config.PackageManager {
 	case "vcpkg":
-		files["vcpkg.json"] = templates.VcpkgJson(config.ProjectName, config.TestFramework)
+		files["vcpkg.json"] = r.render("vcpkg.json.tmpl")
 	case "conan":
-		files["conanfile.txt"] = templates.ConanfileTxt(config.TestFramework)
+		files["conanfile.txt"] = r.render("conanfile.txt.tmpl")
 	}
 
-	// Tooling configs
-	if config.UseClangFormat {
-		files[".clang-format"] = templates.ClangFormat()
-	}
-	if config.UseClangTidy {
-		files[".clang-tidy"] = templates.ClangTidy(config.Standard)
-	}
-	files[".editorconfig"] = templates.EditorConfig()
+	// Editor config
+	files[".editorconfig"] = r.render(".editorconfig.tmpl")
 
 	// License
 	if config.License != "none" {
-		year := time.Now().Format("2006")
-		files["LICENSE"] = templates.License(config.License, config.AuthorName, year)
+		files["LICENSE"] = r.render("LICENSE.tmpl")
 	}
 
 	// Git files
-	files[".gitignore"] = templates.GitIgnore()
+	files[".gitignore"] = r.render(".gitignore.tmpl")
 
 	// Documentation
-	files["README.md"] = generateReadme(config)
-
-	// VSCode configuration
-	if config.IncludeVSCode {
-		files[".vscode/settings.json"] = templates.VSCodeSettings()
-		files[".vscode/extensions.json"] = templates.VSCodeExtensions()
-		files[".vscode/launch.json"] = templates.VSCodeLaunch(config.ProjectName, config.ProjectType)
-		files[".vscode/tasks.json"] = templates.VSCodeTasks()
-	}
-
-	// Docker
-	if config.UseDocker {
-		if config.ProjectType == "executable" {
-			files["Dockerfile"] = templates.Dockerfile(config.ProjectName, config.Standard)
-		}
-		files[".dockerignore"] = templates.DockerIgnore()
-		files[".devcontainer/devcontainer.json"] = templates.DevContainer(config.ProjectName)
-	}
+	files["README.md"] = r.render("README.md.tmpl")
 
-	// Pre-commit
-	if config.UsePreCommit {
-		files[".pre-commit-config.yaml"] = templates.PreCommitConfig()
+	if r.err != nil {
+		return nil, r.err
 	}
 
-	// CI
-	if config.IncludeCI {
-		files[".github/workflows/ci.yml"] = templates.GitHubActionsCIFull(
-			config.ProjectName,
-			config.PackageManager,
-			config.TestFramework,
-			config.UseSanitizers,
-			config.UseCoverage,
-		)
-		files[".github/dependabot.yml"] = templates.GitHubDependabot()
+	if pack != nil {
+		if !pack.IncludesBuiltin() {
+			dirs = nil
+			files = make(map[string]string)
+		}
+		if err := pack.renderFiles(data, files); err != nil {
+			return nil, err
+		}
 	}
 
-	// Write all files
+	// Drop templates that rendered to nothing
 	for filename, content := range files {
 		if content == "" {
-			continue
+			delete(files, filename)
 		}
-		path := filepath.Join(config.OutputDir, filename)
-
-		// Ensure parent directory exists
-		dir := filepath.Dir(path)
-		if err := os.MkdirAll(dir, 0755); err != nil {
-			return fmt.Errorf("failed to create directory for %s: %w", filename, err)
-		}
-
-		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
-			return fmt.Errorf("failed to write %s: %w", filename, err)
-		}
-	}
-
-	return nil
-}
-
-// generateRootCMakeLists creates the main CMakeLists.txt with all features
-func generateRootCMakeLists(config *Config) string {
-	var sb strings.Builder
-
-	// Determine language-specific settings
-	var langSetting string
-	var stdSetting string
-	if config.IsC() {
-		langSetting = "C"
-		stdSetting = fmt.Sprintf(`# Set C standard
-set(CMAKE_C_STANDARD %s)
-set(CMAKE_C_STANDARD_REQUIRED ON)
-set(CMAKE_C_EXTENSIONS OFF)`, config.Standard)
-	} else {
-		langSetting = "CXX"
-		stdSetting = fmt.Sprintf(`# Set C++ standard
-set(CMAKE_CXX_STANDARD %s)
-set(CMAKE_CXX_STANDARD_REQUIRED ON)
-set(CMAKE_CXX_EXTENSIONS OFF)`, config.Standard)
-	}
-
-	sb.WriteString(fmt.Sprintf(`cmake_minimum_required(VERSION 3.21)
-
-project(%s
-    VERSION 0.1.0
-    DESCRIPTION "%s"
-    LANGUAGES %s
-)
-
-# Prevent in-source builds
-if(CMAKE_SOURCE_DIR STREQUAL CMAKE_BINARY_DIR)
-    message(FATAL_ERROR "In-source builds are not allowed. Please use a separate build directory.")
-endif()
-
-%s
-
-# Export compile commands for IDE/tooling support
-set(CMAKE_EXPORT_COMPILE_COMMANDS ON)
-
-# Include custom CMake modules
-list(APPEND CMAKE_MODULE_PATH "${CMAKE_CURRENT_SOURCE_DIR}/cmake")
-
-`, config.ProjectName, config.Description, langSetting, stdSetting))
-
-	// Include CMake modules
-	sb.WriteString("# Include CMake modules\n")
-	sb.WriteString("include(CompilerWarnings)\n")
-
-	if config.UseSanitizers {
-		sb.WriteString("include(Sanitizers)\n")
-	}
-	if config.UseCoverage {
-		sb.WriteString("include(Coverage)\n")
-	}
-	if config.UseClangTidy {
-		sb.WriteString("include(StaticAnalysis)\n")
-	}
-	if config.UseDoxygen {
-		sb.WriteString("include(Doxygen)\n")
-	}
-	if config.PackageManager == "cpm" {
-		sb.WriteString("include(CPM)\n")
-	}
-
-	sb.WriteString("\n")
-
-	// Determine source file extension
-	srcExt := ".cpp"
-	if config.IsC() {
-		srcExt = ".c"
-	}
-
-	// Add target based on project type
-	switch config.ProjectType {
-	case "executable":
-		sb.WriteString(fmt.Sprintf(`# Main executable
-add_executable(${PROJECT_NAME}
-    src/main%s
-)
-
-target_include_directories(${PROJECT_NAME}
-    PRIVATE
-        $<BUILD_INTERFACE:${CMAKE_CURRENT_SOURCE_DIR}/include>
-)
-
-`, srcExt))
-	case "static":
-		sb.WriteString(fmt.Sprintf(`# Library target
-add_library(${PROJECT_NAME} STATIC
-    src/%s%s
-)
-
-# Create alias for use with FetchContent/subdirectory
-add_library(${PROJECT_NAME}::${PROJECT_NAME} ALIAS ${PROJECT_NAME})
-
-target_include_directories(${PROJECT_NAME}
-    PUBLIC
-        $<BUILD_INTERFACE:${CMAKE_CURRENT_SOURCE_DIR}/include>
-        $<INSTALL_INTERFACE:include>
-)
-
-`, config.ProjectName, srcExt))
-	case "header-only":
-		sb.WriteString(`# Header-only library
-add_library(${PROJECT_NAME} INTERFACE)
-
-# Create alias for use with FetchContent/subdirectory
-add_library(${PROJECT_NAME}::${PROJECT_NAME} ALIAS ${PROJECT_NAME})
-
-target_include_directories(${PROJECT_NAME}
-    INTERFACE
-        $<BUILD_INTERFACE:${CMAKE_CURRENT_SOURCE_DIR}/include>
-        $<INSTALL_INTERFACE:include>
-)
-
-`)
-	}
-
-	// Apply compiler warnings
-	sb.WriteString("# Apply compiler warnings\n")
-	sb.WriteString("set_project_warnings(${PROJECT_NAME})\n\n")
-
-	// Apply sanitizers
-	if config.UseSanitizers {
-		sb.WriteString("# Apply sanitizers (if enabled)\n")
-		sb.WriteString("enable_sanitizers(${PROJECT_NAME})\n\n")
-	}
-
-	// Apply coverage
-	if config.UseCoverage {
-		sb.WriteString("# Apply code coverage (if enabled)\n")
-		sb.WriteString("enable_coverage(${PROJECT_NAME})\n\n")
-	}
-
-	// Apply static analysis
-	if config.UseClangTidy {
-		sb.WriteString("# Apply static analysis (if enabled)\n")
-		sb.WriteString("enable_static_analysis(${PROJECT_NAME})\n\n")
-	}
-
-	// Testing
-	if config.TestFramework != "none" {
-		sb.WriteString(`# Testing
-option(BUILD_TESTS "Build the tests" ON)
-if(BUILD_TESTS)
-    enable_testing()
-    add_subdirectory(tests)
-endif()
-
-`)
-	}
-
-	// Benchmarks
-	if config.IncludeBenchmark && config.ProjectType != "executable" {
-		sb.WriteString(`# Benchmarks
-option(BUILD_BENCHMARKS "Build the benchmarks" OFF)
-if(BUILD_BENCHMARKS)
-    add_subdirectory(benchmarks)
-endif()
-
-`)
-	}
-
-	// Documentation
-	if config.UseDoxygen {
-		sb.WriteString("# Documentation\n")
-		sb.WriteString("enable_docs()\n\n")
-	}
-
-	// Coverage target
-	if config.UseCoverage {
-		sb.WriteString("# Coverage report target\n")
-		sb.WriteString("add_coverage_target()\n\n")
-	}
-
-	// Install rules for libraries
-	if config.ProjectType != "executable" {
-		sb.WriteString(`# Installation rules
-include(GNUInstallDirs)
-install(TARGETS ${PROJECT_NAME}
-    EXPORT ${PROJECT_NAME}Targets
-    LIBRARY DESTINATION ${CMAKE_INSTALL_LIBDIR}
-    ARCHIVE DESTINATION ${CMAKE_INSTALL_LIBDIR}
-    RUNTIME DESTINATION ${CMAKE_INSTALL_BINDIR}
-    INCLUDES DESTINATION ${CMAKE_INSTALL_INCLUDEDIR}
-)
-
-install(DIRECTORY include/
-    DESTINATION ${CMAKE_INSTALL_INCLUDEDIR}
-)
-
-install(EXPORT ${PROJECT_NAME}Targets
-    FILE ${PROJECT_NAME}Targets.cmake
-    NAMESPACE ${PROJECT_NAME}::
-    DESTINATION ${CMAKE_INSTALL_LIBDIR}/cmake/${PROJECT_NAME}
-)
-`)
-	}
-
-	return sb.String()
-}
-
-// generateReadme creates a comprehensive README.md
-func generateReadme(config *Config) string {
-	var sb strings.Builder
-
-	sb.WriteString(fmt.Sprintf("# %s\n\n", config.ProjectName))
-	sb.WriteString(fmt.Sprintf("%s\n\n", config.Description))
-
-	// Determine language label
-	langLabel := "C++"
-	badgeLabel := "C%2B%2B"
-	if config.IsC() {
-		langLabel = "C"
-		badgeLabel = "C"
-	}
-
-	// Badges
-	if config.IncludeCI {
-		sb.WriteString("![CI](https://github.com/USERNAME/" + config.ProjectName + "/workflows/CI/badge.svg)\n")
-	}
-	if config.License != "none" {
-		sb.WriteString(fmt.Sprintf("![License](https://img.shields.io/badge/license-%s-blue.svg)\n", config.License))
-	}
-	sb.WriteString(fmt.Sprintf("![%s%s](https://img.shields.io/badge/%s-%s-blue.svg)\n\n", langLabel, config.Standard, badgeLabel, config.Standard))
-
-	// Features
-	sb.WriteString("## Features\n\n")
-	sb.WriteString(fmt.Sprintf("- Modern %s%s\n", langLabel, config.Standard))
-	sb.WriteString("- CMake 3.21+ with presets\n")
-	if config.TestFramework != "none" {
-		sb.WriteString(fmt.Sprintf("- %s testing framework\n", config.TestFramework))
-	}
-	if config.UseClangFormat {
-		sb.WriteString("- clang-format for code formatting\n")
-	}
-	if config.UseClangTidy {
-		sb.WriteString("- clang-tidy for static analysis\n")
-	}
-	if config.UseSanitizers {
-		sb.WriteString("- Address, UB, and Thread sanitizers\n")
-	}
-	if config.UseCoverage {
-		sb.WriteString("- Code coverage support\n")
-	}
-	if config.IncludeCI {
-		sb.WriteString("- GitHub Actions CI/CD\n")
-	}
-	sb.WriteString("\n")
-
-	// Requirements
-	sb.WriteString("## Requirements\n\n")
-	sb.WriteString("- CMake 3.21 or higher\n")
-	if config.IsC() {
-		sb.WriteString(fmt.Sprintf("- C%s compatible compiler (GCC, Clang, MSVC)\n", config.Standard))
-	} else {
-		sb.WriteString(fmt.Sprintf("- C++%s compatible compiler (GCC 10+, Clang 12+, MSVC 2019+)\n", config.Standard))
-	}
-	if config.PackageManager == "vcpkg" {
-		sb.WriteString("- vcpkg (optional, for dependency management)\n")
-	} else if config.PackageManager == "conan" {
-		sb.WriteString("- Conan (optional, for dependency management)\n")
-	}
-	sb.WriteString("\n")
-
-	// Building
-	sb.WriteString("## Building\n\n")
-	sb.WriteString("```bash\n")
-	sb.WriteString("# Configure (debug build)\n")
-	sb.WriteString("cmake --preset debug\n\n")
-	sb.WriteString("# Build\n")
-	sb.WriteString("cmake --build --preset debug\n\n")
-	sb.WriteString("# Or for release\n")
-	sb.WriteString("cmake --preset release\n")
-	sb.WriteString("cmake --build --preset release\n")
-	sb.WriteString("```\n\n")
-
-	// Testing
-	if config.TestFramework != "none" {
-		sb.WriteString("## Testing\n\n")
-		sb.WriteString("```bash\n")
-		sb.WriteString("# Run tests\n")
-		sb.WriteString("ctest --preset debug\n\n")
-		sb.WriteString("# Or with verbose output\n")
-		sb.WriteString("ctest --preset debug --output-on-failure\n")
-		sb.WriteString("```\n\n")
-	}
-
-	// Sanitizers
-	if config.UseSanitizers {
-		sb.WriteString("## Sanitizers\n\n")
-		sb.WriteString("```bash\n")
-		sb.WriteString("# AddressSanitizer\n")
-		sb.WriteString("cmake --preset asan\n")
-		sb.WriteString("cmake --build --preset asan\n\n")
-		sb.WriteString("# UndefinedBehaviorSanitizer\n")
-		sb.WriteString("cmake --preset ubsan\n")
-		sb.WriteString("cmake --build --preset ubsan\n\n")
-		sb.WriteString("# ThreadSanitizer\n")
-		sb.WriteString("cmake --preset tsan\n")
-		sb.WriteString("cmake --build --preset tsan\n")
-		sb.WriteString("```\n\n")
-	}
-
-	// Coverage
-	if config.UseCoverage {
-		sb.WriteString("## Code Coverage\n\n")
-		sb.WriteString("```bash\n")
-		sb.WriteString("cmake --preset coverage\n")
-		sb.WriteString("cmake --build --preset coverage\n")
-		sb.WriteString("ctest --preset debug\n")
-		sb.WriteString("cmake --build --preset coverage --target coverage\n")
-		sb.WriteString("# Open build/coverage/coverage_report/index.html\n")
-		sb.WriteString("```\n\n")
-	}
-
-	// Docker
-	if config.UseDocker {
-		sb.WriteString("## Docker\n\n")
-		sb.WriteString("```bash\n")
-		sb.WriteString("# Build image\n")
-		sb.WriteString(fmt.Sprintf("docker build -t %s .\n\n", config.ProjectName))
-		sb.WriteString("# Run container\n")
-		sb.WriteString(fmt.Sprintf("docker run --rm %s\n", config.ProjectName))
-		sb.WriteString("```\n\n")
-		sb.WriteString("### VS Code Dev Container\n\n")
-		sb.WriteString("Open the project in VS Code and click \"Reopen in Container\" when prompted.\n\n")
-	}
-
-	// Project structure
-	sb.WriteString("## Project Structure\n\n")
-	sb.WriteString("```\n")
-	sb.WriteString(config.ProjectName + "/\n")
-	sb.WriteString("├── CMakeLists.txt          # Main CMake configuration\n")
-	sb.WriteString("├── CMakePresets.json       # CMake presets for easy building\n")
-	sb.WriteString("├── cmake/                  # CMake modules\n")
-	sb.WriteString("│   ├── CompilerWarnings.cmake\n")
-	if config.UseSanitizers {
-		sb.WriteString("│   ├── Sanitizers.cmake\n")
-	}
-	if config.UseCoverage {
-		sb.WriteString("│   ├── Coverage.cmake\n")
-	}
-	sb.WriteString("├── include/                # Public headers\n")
-	sb.WriteString(fmt.Sprintf("│   └── %s/\n", config.ProjectName))
-	sb.WriteString("├── src/                    # Source files\n")
-	if config.TestFramework != "none" {
-		sb.WriteString("├── tests/                  # Test files\n")
-	}
-	if config.IncludeVSCode {
-		sb.WriteString("├── .vscode/                # VS Code configuration\n")
-	}
-	if config.UseDocker {
-		sb.WriteString("├── .devcontainer/          # Dev container configuration\n")
-		sb.WriteString("├── Dockerfile\n")
-	}
-	sb.WriteString("└── README.md\n")
-	sb.WriteString("```\n\n")
-
-	// License
-	if config.License != "none" {
-		sb.WriteString("## License\n\n")
-		licenseName := map[string]string{
-			"mit":     "MIT",
-			"apache2": "Apache 2.0",
-			"gpl3":    "GPL 3.0",
-			"bsd3":    "BSD 3-Clause",
-		}[config.License]
-		sb.WriteString(fmt.Sprintf("This project is licensed under the %s License - see the [LICENSE](LICENSE) file for details.\n", licenseName))
 	}
 
-	return sb.String()
+	return &Plan{Dirs: dirs, Files: files}, nil
 }
@@ -0,0 +1,49 @@
+package scaffold
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PrintInfo prints how a project was generated and which generated
+// files were changed since
+func PrintInfo(m *Manifest, statuses []FileStatus) {
+	c := m.Config
+
+	fmt.Println(titleStyle.Render(fmt.Sprintf("%s (generated by cppinit %s)", c.ProjectName, m.Version)))
+
+	langLabel := "C++"
+	if c.IsC() {
+		langLabel = "C"
+	}
+	details := []string{fmt.Sprintf("%s%s %s", langLabel, c.Standard, c.ProjectType)}
+	if c.TestFramework != "none" {
+		details = append(details, c.TestFramework)
+	}
+	if c.PackageManager != "none" {
+		details = append(details, c.PackageManager)
+	}
+	if c.License != "none" {
+		details = append(details, c.License+" license")
+	}
+	fmt.Printf("  %s\n\n", strings.Join(details, " · "))
+
+	var changed int
+	for _, s := range statuses {
+		switch s.State {
+		case FileModified:
+			fmt.Printf("  %s %s\n", pathStyle.Render("modified"), s.Path)
+			changed++
+		case FileMissing:
+			fmt.Printf("  %s  %s\n", dimStyle.Render("missing"), s.Path)
+			changed++
+		}
+	}
+
+	if changed == 0 {
+		fmt.Println(successStyle.Render(fmt.Sprintf("All %d generated files are unchanged", len(statuses))))
+		return
+	}
+	fmt.Println()
+	fmt.Println(dimStyle.Render(fmt.Sprintf("%d of %d generated files changed since generation", changed, len(statuses))))
+}
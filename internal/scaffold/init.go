@@ -0,0 +1,112 @@
+package scaffold
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Source and header extensions init recognises
+var (
+	cSourceExts   = []string{".c"}
+	cppSourceExts = []string{".cpp", ".cc", ".cxx", ".c++"}
+	headerExts    = []string{".h", ".hpp", ".hh", ".hxx", ".inl"}
+)
+
+// mainPattern matches a definition of main in C or C++
+var mainPattern = regexp.MustCompile(`\bint\s+main\s*\(`)
+
+// ExistingCode is the C and C++ code found in a directory before init
+type ExistingCode struct {
+	Sources []string // below src/, slash-separated relative to the directory
+	Headers []string // below include/
+	HasMain bool     // one of the sources defines main
+	C, Cpp  bool     // languages of the sources
+}
+
+// DetectCode looks for sources in dir/src and headers in dir/include
+func DetectCode(dir string) (*ExistingCode, error) {
+	code := &ExistingCode{}
+	for _, sub := range []string{"src", "include"} {
+		root := filepath.Join(dir, sub)
+		err := filepath.WalkDir(root, func(filename string, d fs.DirEntry, err error) error {
+			if errors.Is(err, fs.ErrNotExist) && filename == root {
+				return filepath.SkipDir
+			}
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if filename != root && strings.HasPrefix(d.Name(), ".") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			rel, err := filepath.Rel(dir, filename)
+			if err != nil {
+				return err
+			}
+			rel = filepath.ToSlash(rel)
+
+			ext := strings.ToLower(path.Ext(rel))
+			switch {
+			case sub == "include" && slices.Contains(headerExts, ext):
+				code.Headers = append(code.Headers, rel)
+			case sub == "src" && (slices.Contains(cSourceExts, ext) || slices.Contains(cppSourceExts, ext)):
+				code.Sources = append(code.Sources, rel)
+				if slices.Contains(cSourceExts, ext) {
+					code.C = true
+				} else {
+					code.Cpp = true
+				}
+				if !code.HasMain {
+					data, err := os.ReadFile(filename)
+					if err != nil {
+						return fmt.Errorf("failed to read %s: %w", rel, err)
+					}
+					code.HasMain = mainPattern.Match(data)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", root, err)
+		}
+	}
+	sort.Strings(code.Sources)
+	sort.Strings(code.Headers)
+	return code, nil
+}
+
+// Empty reports whether no code was found
+func (e *ExistingCode) Empty() bool {
+	return len(e.Sources) == 0 && len(e.Headers) == 0
+}
+
+// Apply makes the config build the existing code instead of generating
+// placeholders. The language and project type are inferred from the code
+// unless they were chosen explicitly.
+func (e *ExistingCode) Apply(c *Config, languageGiven, typeGiven bool) {
+	if !languageGiven && e.C && !e.Cpp {
+		c.Language = "c"
+	}
+	if !typeGiven {
+		switch {
+		case e.HasMain:
+			c.ProjectType = "executable"
+		case len(e.Sources) > 0:
+			c.ProjectType = "static"
+		case len(e.Headers) > 0:
+			c.ProjectType = "header-only"
+		}
+	}
+	c.Sources = e.Sources
+	c.OwnHeaders = len(e.Headers) > 0
+}
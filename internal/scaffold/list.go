@@ -0,0 +1,61 @@
+package scaffold
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OptionValues returns the valid values of every enum option by config key;
+// options that depend on the language have _c and _cpp variants
+func OptionValues() map[string][]string {
+	return map[string][]string{
+		"language":        Languages,
+		"standard_c":      CStandards,
+		"standard_cpp":    CppStandards,
+		"type":            ProjectTypes,
+		"tests_c":         CTestFrameworks,
+		"tests_cpp":       CppTestFrameworks,
+		"package_manager": PackageManagers,
+		"license":         Licenses,
+	}
+}
+
+// FeatureReports describes the features add and remove accept
+func FeatureReports() []FeatureReport {
+	var features []FeatureReport
+	for _, f := range Features {
+		features = append(features, FeatureReport{Name: f.Name, Description: f.Description})
+	}
+	return features
+}
+
+// PrintOptionValues lists the valid values of every enum option
+func PrintOptionValues() {
+	fmt.Println(titleStyle.Render("Project options"))
+	rows := []struct {
+		flag   string
+		values []string
+	}{
+		{"-lang", Languages},
+		{"-std (C)", CStandards},
+		{"-std (C++)", CppStandards},
+		{"-type", ProjectTypes},
+		{"-tests (C)", CTestFrameworks},
+		{"-tests (C++)", CppTestFrameworks},
+		{"-pkg", PackageManagers},
+		{"-license", Licenses},
+	}
+	for _, r := range rows {
+		fmt.Printf("  %-13s %s\n", r.flag, strings.Join(r.values, ", "))
+	}
+	fmt.Println()
+}
+
+// PrintFeatures lists the features add and remove accept
+func PrintFeatures() {
+	fmt.Println(titleStyle.Render("Features (cppinit add / remove)"))
+	for _, f := range Features {
+		fmt.Printf("  %-13s %s\n", f.Name, f.Description)
+	}
+	fmt.Println()
+}
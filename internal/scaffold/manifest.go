@@ -0,0 +1,196 @@
+package scaffold
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// ManifestFile is the name of the generation record written to every project
+const ManifestFile = ".cppinit.json"
+
+// ManifestSchemaVersion is the version of the manifest format
+const ManifestSchemaVersion = 1
+
+// Manifest records how a project was generated
+type Manifest struct {
+	Schema  int                   `json:"schema"`
+	Version string                `json:"version"` // cppinit version
+	Config  *Config               `json:"config"`
+	Files   map[string]FileRecord `json:"files"`
+
+	// Bases is the generated text of the recorded files, the merge base
+	// upgrade needs for files changed both locally and by the templates. A
+	// newer cppinit cannot re-render it with the templates it replaced, so
+	// it is kept as gzip-compressed JSON, encoded in base64.
+	Bases string `json:"bases,omitempty"`
+}
+
+// FileRecord describes one generated file
+type FileRecord struct {
+	SHA256 string `json:"sha256"`
+}
+
+// FileState is how a generated file compares to its recorded hash
+type FileState string
+
+const (
+	FileUnchanged FileState = "unchanged"
+	FileModified  FileState = "modified"
+	FileMissing   FileState = "missing"
+)
+
+// FileStatus is the state of one file listed in a manifest
+type FileStatus struct {
+	Path  string    `json:"path"`
+	State FileState `json:"state"`
+}
+
+// hashContent returns the hex encoded SHA-256 of content
+func hashContent(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// NewManifest records the config and the hash and merge base of every
+// planned file
+func NewManifest(config *Config, version string, plan *Plan) *Manifest {
+	m := &Manifest{
+		Schema:  ManifestSchemaVersion,
+		Version: version,
+		Config:  config,
+		Files:   make(map[string]FileRecord, len(plan.Files)),
+	}
+	bases := make(map[string]string, len(plan.Files))
+	for filename, content := range plan.Files {
+		if filename == ManifestFile {
+			continue
+		}
+		m.Files[filename] = FileRecord{SHA256: hashContent([]byte(content))}
+		bases[filename] = content
+	}
+	m.Bases = encodeBases(bases)
+	return m
+}
+
+// encodeBases compresses the generated text of every file for Manifest.Bases
+func encodeBases(bases map[string]string) string {
+	data, err := json.Marshal(bases)
+	if err != nil {
+		panic(fmt.Sprintf("failed to encode merge bases: %v", err))
+	}
+	var buf bytes.Buffer
+	gz, _ := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	gz.Write(data)
+	gz.Close()
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+// MergeBases returns the recorded generated text of every file, keyed by
+// path. Files without a recorded base are missing from the map.
+func (m *Manifest) MergeBases() (map[string]string, error) {
+	bases := make(map[string]string)
+	if m.Bases == "" {
+		return bases, nil
+	}
+	data, err := base64.StdEncoding.DecodeString(m.Bases)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the merge bases in %s: %w", ManifestFile, err)
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress the merge bases in %s: %w", ManifestFile, err)
+	}
+	if err := json.NewDecoder(gz).Decode(&bases); err != nil {
+		return nil, fmt.Errorf("failed to parse the merge bases in %s: %w", ManifestFile, err)
+	}
+	return bases, nil
+}
+
+// Render encodes the manifest as indented JSON
+func (m *Manifest) Render() string {
+	// Maps are encoded with sorted keys, so the output is stable
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		panic(fmt.Sprintf("failed to encode manifest: %v", err))
+	}
+	return string(data) + "\n"
+}
+
+// AddManifest adds the generation record for config to the plan
+func (p *Plan) AddManifest(config *Config, version string) {
+	p.Files[ManifestFile] = NewManifest(config, version, p).Render()
+}
+
+// NoManifestError is returned for directories without a generation record
+type NoManifestError struct {
+	Dir string
+}
+
+func (e *NoManifestError) Error() string {
+	return fmt.Sprintf("%s has no %s; it was not generated by cppinit or predates generation records", e.Dir, ManifestFile)
+}
+
+// Unwrap makes errors.Is(err, fs.ErrNotExist) hold
+func (e *NoManifestError) Unwrap() error {
+	return fs.ErrNotExist
+}
+
+// ReadManifest loads the generation record of the project in dir
+func ReadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, &NoManifestError{Dir: dir}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", ManifestFile, err)
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ManifestFile, err)
+	}
+	if m.Schema > ManifestSchemaVersion {
+		return nil, fmt.Errorf("%s uses schema %d, but this cppinit only understands schema %d; please upgrade cppinit",
+			ManifestFile, m.Schema, ManifestSchemaVersion)
+	}
+	if m.Config == nil {
+		return nil, fmt.Errorf("%s does not record a project config", ManifestFile)
+	}
+	m.Config.OutputDir = dir
+	return &m, nil
+}
+
+// Status compares every recorded file against its current content in dir
+func (m *Manifest) Status(dir string) ([]FileStatus, error) {
+	paths := make([]string, 0, len(m.Files))
+	for filename := range m.Files {
+		paths = append(paths, filename)
+	}
+	sort.Strings(paths)
+
+	statuses := make([]FileStatus, 0, len(paths))
+	for _, filename := range paths {
+		content, err := os.ReadFile(filepath.Join(dir, filename))
+		state := FileUnchanged
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			state = FileMissing
+		case err != nil:
+			return nil, fmt.Errorf("failed to read %s: %w", filename, err)
+		case hashContent(content) != m.Files[filename].SHA256:
+			state = FileModified
+		}
+		statuses = append(statuses, FileStatus{Path: filename, State: state})
+	}
+	return statuses, nil
+}
@@ -0,0 +1,84 @@
+package scaffold
+
+import (
+	"slices"
+	"strings"
+)
+
+// Conflict marker labels used by Merge3
+const (
+	mergeLabelOurs   = "current"
+	mergeLabelBase   = "generated"
+	mergeLabelTheirs = "cppinit"
+)
+
+// matchLines maps every line of a to the line of b it is paired with in the
+// longest common subsequence, or -1
+func matchLines(a, b []string) []int {
+	match := make([]int, len(a))
+	for i := range match {
+		match[i] = -1
+	}
+	for _, op := range diffLines(a, b) {
+		if op.kind == ' ' {
+			match[op.a] = op.b
+		}
+	}
+	return match
+}
+
+// Merge3 merges the changes made from base to ours and from base to theirs
+// line by line. Regions changed on both sides in different ways are written
+// with diff3 style conflict markers; the number of conflicting regions is
+// returned alongside the merged text.
+func Merge3(base, ours, theirs string) (string, int) {
+	baseLines, ourLines, theirLines := splitLines(base), splitLines(ours), splitLines(theirs)
+	matchOurs := matchLines(baseLines, ourLines)
+	matchTheirs := matchLines(baseLines, theirLines)
+
+	var out []string
+	conflicts := 0
+	i, a, b := 0, 0, 0
+
+	for i < len(baseLines) || a < len(ourLines) || b < len(theirLines) {
+		// Stable line: unchanged on both sides
+		if i < len(baseLines) && matchOurs[i] == a && matchTheirs[i] == b {
+			out = append(out, baseLines[i])
+			i, a, b = i+1, a+1, b+1
+			continue
+		}
+
+		// Find the next base line that both sides kept
+		j := i
+		for j < len(baseLines) && (matchOurs[j] < 0 || matchTheirs[j] < 0) {
+			j++
+		}
+		endA, endB := len(ourLines), len(theirLines)
+		if j < len(baseLines) {
+			endA, endB = matchOurs[j], matchTheirs[j]
+		}
+
+		baseChunk, ourChunk, theirChunk := baseLines[i:j], ourLines[a:endA], theirLines[b:endB]
+		switch {
+		case slices.Equal(ourChunk, baseChunk):
+			out = append(out, theirChunk...)
+		case slices.Equal(theirChunk, baseChunk), slices.Equal(ourChunk, theirChunk):
+			out = append(out, ourChunk...)
+		default:
+			conflicts++
+			out = append(out, "<<<<<<< "+mergeLabelOurs)
+			out = append(out, ourChunk...)
+			out = append(out, "||||||| "+mergeLabelBase)
+			out = append(out, baseChunk...)
+			out = append(out, "=======")
+			out = append(out, theirChunk...)
+			out = append(out, ">>>>>>> "+mergeLabelTheirs)
+		}
+		i, a, b = j, endA, endB
+	}
+
+	if len(out) == 0 {
+		return "", conflicts
+	}
+	return strings.Join(out, "\n") + "\n", conflicts
+}
@@ -0,0 +1,285 @@
+package scaffold
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Names are the identifiers generated code derives from the project name.
+// A project name such as 2d-engine or class is a fine directory name but
+// not a valid namespace, macro or file name, so each is derived separately
+// and can be overridden in the config.
+type Names struct {
+	Identifier  string `json:"identifier"`   // C function prefix, C++ namespace and test suite names
+	MacroPrefix string `json:"macro_prefix"` // include guards and macros
+	Target      string `json:"target"`       // CMake project and target name
+	FileStem    string `json:"file_stem"`    // include directory, header and source file names
+}
+
+// Names returns the names of the project: the overrides of the config, or
+// else the names derived from the project name
+func (c *Config) Names() Names {
+	n := DeriveNames(c.ProjectName)
+	if c.Identifier != "" {
+		n.Identifier = c.Identifier
+		n.MacroPrefix = macroName(c.Identifier)
+	}
+	if c.MacroPrefix != "" {
+		n.MacroPrefix = c.MacroPrefix
+	}
+	if c.Target != "" {
+		n.Target = c.Target
+	}
+	if c.FileStem != "" {
+		n.FileStem = c.FileStem
+	}
+	return n
+}
+
+// DeriveNames derives every name from a project name. Accented Latin
+// letters are folded to ASCII, other characters that are not allowed
+// become underscores, and names that would start with a digit, be empty or
+// be a keyword are adjusted. A target CMake or the project already uses
+// gets a _project suffix.
+func DeriveNames(project string) Names {
+	id := identifierName(project)
+	target := replaceDisallowed(project, isTargetRune)
+	if TargetConflict(target) != "" {
+		target += "_project"
+	}
+	return Names{
+		Identifier:  id,
+		MacroPrefix: macroName(id),
+		Target:      target,
+		FileStem:    replaceDisallowed(project, isFileRune),
+	}
+}
+
+// RenamedTarget explains why the CMake target derived from the project
+// name differs from it, or returns "" when it does not
+func (c *Config) RenamedTarget() string {
+	if c.Target != "" {
+		return ""
+	}
+	base := replaceDisallowed(c.ProjectName, isTargetRune)
+	if reason := TargetConflict(base); reason != "" {
+		return fmt.Sprintf("%s is %s, so the CMake target is named %s", base, reason, c.Names().Target)
+	}
+	return ""
+}
+
+// TargetConflict returns who already uses a CMake target name, or "" when a
+// project may use it. Names are compared case-insensitively because build
+// files of targets that differ only in case clash on Windows and macOS.
+func TargetConflict(target string) string {
+	return reservedTargets[strings.ToLower(target)]
+}
+
+// reservedTargets maps the lowercased target names a project cannot use to
+// who uses them: CMake generators, CTest, the targets of generated projects
+// and those of the dependencies they fetch
+var reservedTargets = func() map[string]string {
+	m := map[string]string{}
+	reserve := func(reason, names string) {
+		for _, name := range strings.Fields(names) {
+			m[strings.ToLower(name)] = reason
+		}
+	}
+	reserve("reserved by CMake", `all clean help install list_install_components edit_cache rebuild_cache
+		depend preinstall package package_source test ALL_BUILD ZERO_CHECK RUN_TESTS INSTALL PACKAGE`)
+	for _, model := range []string{"Experimental", "Nightly", "Continuous"} {
+		for _, step := range []string{"", "Start", "Update", "Configure", "Build", "Test", "Coverage", "MemCheck", "Submit"} {
+			reserve("reserved by CTest", model+step)
+		}
+	}
+	reserve("reserved by CTest", "NightlyMemoryCheck")
+	reserve("taken by the generated test executable", "tests")
+	reserve("taken by the generated benchmark executable", "benchmarks")
+	reserve("taken by the generated documentation target", "docs")
+	reserve("taken by the generated coverage report target", "coverage")
+	reserve("taken by Unity", "unity")
+	reserve("taken by GoogleTest", "gtest gtest_main gmock gmock_main")
+	reserve("taken by doctest", "doctest doctest_with_main")
+	reserve("taken by Catch2", "Catch2 Catch2WithMain")
+	reserve("taken by Google Benchmark", "benchmark benchmark_main")
+	return m
+}()
+
+// identifierName derives a C and C++ identifier from s
+func identifierName(s string) string {
+	id := replaceDisallowed(s, isIdentRune)
+	if id[0] >= '0' && id[0] <= '9' {
+		id = "lib" + id
+	}
+	if reservedIdentifiers[id] {
+		id += "_"
+	}
+	return id
+}
+
+// macroName derives an UPPER_SNAKE_CASE macro prefix from an identifier,
+// splitting camelCase words: HttpServer and HTTPServer become HTTP_SERVER
+func macroName(id string) string {
+	runes := []rune(strings.Trim(id, "_"))
+	var sb strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				sb.WriteByte('_')
+			}
+		}
+		sb.WriteRune(unicode.ToUpper(r))
+	}
+	return collapseUnderscores(sb.String())
+}
+
+// replaceDisallowed folds s to ASCII and replaces every run of runes
+// allowed rejects with an underscore. Underscores are not doubled or left at
+// either end, where identifiers reserve them, and an empty result becomes
+// "project".
+func replaceDisallowed(s string, allowed func(rune) bool) string {
+	var sb strings.Builder
+	for _, r := range foldLatin(s) {
+		if allowed(r) {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteByte('_')
+		}
+	}
+	result := strings.Trim(collapseUnderscores(sb.String()), "_")
+	if result == "" {
+		return "project"
+	}
+	return result
+}
+
+// collapseUnderscores replaces runs of underscores with a single one
+func collapseUnderscores(s string) string {
+	for strings.Contains(s, "__") {
+		s = strings.ReplaceAll(s, "__", "_")
+	}
+	return s
+}
+
+func isASCIIAlnum(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9'
+}
+
+func isIdentRune(r rune) bool {
+	return isASCIIAlnum(r) || r == '_'
+}
+
+// isTargetRune reports whether CMake allows r in a target name
+func isTargetRune(r rune) bool {
+	return isASCIIAlnum(r) || strings.ContainsRune("_.+-", r)
+}
+
+// isFileRune reports whether r is portable in a file name
+func isFileRune(r rune) bool {
+	return isASCIIAlnum(r) || strings.ContainsRune("_.-", r)
+}
+
+// latinFold maps accented Latin letters to their ASCII base letters
+var latinFold = func() map[rune]string {
+	m := map[rune]string{'ß': "ss", 'æ': "ae", 'Æ': "AE", 'œ': "oe", 'Œ': "OE", 'þ': "th", 'Þ': "TH"}
+	for base, letters := range map[string]string{
+		"a": "àáâãäåāăą", "A": "ÀÁÂÃÄÅĀĂĄ", "c": "çćĉċč", "C": "ÇĆĈĊČ", "d": "ďđð", "D": "ĎĐÐ",
+		"e": "èéêëēĕėęě", "E": "ÈÉÊËĒĔĖĘĚ", "g": "ĝğġģ", "G": "ĜĞĠĢ", "h": "ĥħ", "H": "ĤĦ",
+		"i": "ìíîïĩīĭįı", "I": "ÌÍÎÏĨĪĬĮİ", "j": "ĵ", "J": "Ĵ", "k": "ķ", "K": "Ķ",
+		"l": "ĺļľŀł", "L": "ĹĻĽĿŁ", "n": "ñńņňŉ", "N": "ÑŃŅŇ", "o": "òóôõöøōŏő", "O": "ÒÓÔÕÖØŌŎŐ",
+		"r": "ŕŗř", "R": "ŔŖŘ", "s": "śŝşš", "S": "ŚŜŞŠ", "t": "ţťŧ", "T": "ŢŤŦ",
+		"u": "ùúûüũūŭůűų", "U": "ÙÚÛÜŨŪŬŮŰŲ", "w": "ŵ", "W": "Ŵ", "y": "ýÿŷ", "Y": "ÝŶŸ",
+		"z": "źżž", "Z": "ŹŻŽ",
+	} {
+		for _, r := range letters {
+			m[r] = base
+		}
+	}
+	return m
+}()
+
+// foldLatin replaces accented Latin letters in s with ASCII letters
+func foldLatin(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		if ascii, ok := latinFold[r]; ok {
+			sb.WriteString(ascii)
+		} else {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// reservedIdentifiers are the C and C++ keywords, alternative operator
+// tokens and standard names a project identifier must not be
+var reservedIdentifiers = func() map[string]bool {
+	m := map[string]bool{}
+	for _, word := range strings.Fields(`
+		alignas alignof and and_eq asm auto bitand bitor bool break case catch char char8_t
+		char16_t char32_t class compl concept const consteval constexpr constinit const_cast
+		continue co_await co_return co_yield decltype default delete do double dynamic_cast
+		else enum explicit export extern false float for friend goto if inline int long
+		mutable namespace new noexcept not not_eq nullptr operator or or_eq private protected
+		public register reinterpret_cast requires restrict return short signed sizeof static
+		static_assert static_cast struct switch template this thread_local throw true try
+		typedef typeid typename typeof typeof_unqual union unsigned using virtual void
+		volatile wchar_t while xor xor_eq
+		std assert errno NULL EOF`) {
+		m[word] = true
+	}
+	return m
+}()
+
+var (
+	identifierPattern  = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+	macroPrefixPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+	targetPattern      = regexp.MustCompile(`^[A-Za-z0-9_.+-]+$`)
+	fileStemPattern    = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]*$`)
+)
+
+// validateIdentifier checks an identifier override; empty derives it
+func validateIdentifier(s string) error {
+	switch {
+	case s == "":
+		return nil
+	case !identifierPattern.MatchString(s) || strings.Contains(s, "__"):
+		return fmt.Errorf("identifier must start with a letter and contain only ASCII letters, digits and single underscores")
+	case reservedIdentifiers[s]:
+		return fmt.Errorf("identifier %s is a C or C++ keyword or standard name", s)
+	}
+	return nil
+}
+
+// validateMacroPrefix checks a macro prefix override; empty derives it
+func validateMacroPrefix(s string) error {
+	if s != "" && (!macroPrefixPattern.MatchString(s) || strings.Contains(s, "__")) {
+		return fmt.Errorf("macro prefix must start with a letter and contain only uppercase letters, digits and single underscores")
+	}
+	return nil
+}
+
+// validateTarget checks a CMake target name override; empty derives it
+func validateTarget(s string) error {
+	switch {
+	case s == "":
+		return nil
+	case !targetPattern.MatchString(s):
+		return fmt.Errorf("target name may only contain ASCII letters, digits and _ . + -")
+	case TargetConflict(s) != "":
+		return fmt.Errorf("target name %s is %s", s, TargetConflict(s))
+	}
+	return nil
+}
+
+// validateFileStem checks a file stem override; empty derives it
+func validateFileStem(s string) error {
+	if s != "" && !fileStemPattern.MatchString(s) {
+		return fmt.Errorf("file stem must start with a letter, digit or _ and contain only ASCII letters, digits and _ . -")
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package scaffold
+
+import (
+	"strings"
+	"testing"
+)
+
+// reservedTargetTests lists every name of reservedTargets with who uses it
+var reservedTargetTests = func() []struct{ name, reason string } {
+	var tests []struct{ name, reason string }
+	add := func(reason string, names ...string) {
+		for _, name := range names {
+			tests = append(tests, struct{ name, reason string }{name, reason})
+		}
+	}
+	add("reserved by CMake", "all", "clean", "help", "install", "list_install_components", "edit_cache",
+		"rebuild_cache", "depend", "preinstall", "package", "package_source", "test",
+		"ALL_BUILD", "ZERO_CHECK", "RUN_TESTS")
+	add("reserved by CTest",
+		"Experimental", "ExperimentalStart", "ExperimentalUpdate", "ExperimentalConfigure", "ExperimentalBuild",
+		"ExperimentalTest", "ExperimentalCoverage", "ExperimentalMemCheck", "ExperimentalSubmit",
+		"Nightly", "NightlyStart", "NightlyUpdate", "NightlyConfigure", "NightlyBuild",
+		"NightlyTest", "NightlyCoverage", "NightlyMemCheck", "NightlySubmit", "NightlyMemoryCheck",
+		"Continuous", "ContinuousStart", "ContinuousUpdate", "ContinuousConfigure", "ContinuousBuild",
+		"ContinuousTest", "ContinuousCoverage", "ContinuousMemCheck", "ContinuousSubmit")
+	add("taken by the generated test executable", "tests")
+	add("taken by the generated benchmark executable", "benchmarks")
+	add("taken by the generated documentation target", "docs")
+	add("taken by the generated coverage report target", "coverage")
+	add("taken by Unity", "unity")
+	add("taken by GoogleTest", "gtest", "gtest_main", "gmock", "gmock_main")
+	add("taken by doctest", "doctest", "doctest_with_main")
+	add("taken by Catch2", "Catch2", "Catch2WithMain")
+	add("taken by Google Benchmark", "benchmark", "benchmark_main")
+	return tests
+}()
+
+func TestReservedTargetsListed(t *testing.T) {
+	// INSTALL and PACKAGE share their lowercased entry with install and package
+	if len(reservedTargetTests) != len(reservedTargets) {
+		t.Fatalf("reservedTargets has %d names, the test lists %d", len(reservedTargets), len(reservedTargetTests))
+	}
+}
+
+func TestReservedTargets(t *testing.T) {
+	for _, tt := range reservedTargetTests {
+		for _, name := range []string{tt.name, strings.ToLower(tt.name), strings.ToUpper(tt.name)} {
+			if got := TargetConflict(name); got != tt.reason {
+				t.Errorf("TargetConflict(%q) = %q, want %q", name, got, tt.reason)
+			}
+
+			// An explicit -target is rejected
+			want := "target name " + name + " is " + tt.reason
+			if err := validateTarget(name); err == nil || err.Error() != want {
+				t.Errorf("validateTarget(%q) = %v, want %q", name, err, want)
+			}
+			c := DefaultConfig()
+			c.ProjectName, c.Target = "ok", name
+			if err := c.Validate(); err == nil || !strings.Contains(err.Error(), "target: "+want) {
+				t.Errorf("Validate with target %q = %v, want a target problem", name, err)
+			}
+
+			// A derived target is renamed, with a note for the user
+			renamed := name + "_project"
+			if got := DeriveNames(name).Target; got != renamed {
+				t.Errorf("DeriveNames(%q).Target = %q, want %q", name, got, renamed)
+			}
+			c = DefaultConfig()
+			c.ProjectName = name
+			note := name + " is " + tt.reason + ", so the CMake target is named " + renamed
+			if got := c.RenamedTarget(); got != note {
+				t.Errorf("RenamedTarget for %q = %q, want %q", name, got, note)
+			}
+			if err := c.Validate(); err != nil {
+				t.Errorf("Validate for project %q: %v", name, err)
+			}
+		}
+	}
+}
+
+func TestUnreservedTargets(t *testing.T) {
+	for _, name := range []string{"mylib", "testing", "test-utils", "docs2", "gtest-extras"} {
+		if got := TargetConflict(name); got != "" {
+			t.Errorf("TargetConflict(%q) = %q, want none", name, got)
+		}
+		if got := DeriveNames(name).Target; got != name {
+			t.Errorf("DeriveNames(%q).Target = %q, want it unchanged", name, got)
+		}
+		c := DefaultConfig()
+		c.ProjectName = name
+		if got := c.RenamedTarget(); got != "" {
+			t.Errorf("RenamedTarget for %q = %q, want none", name, got)
+		}
+	}
+
+	// An explicit target is used as given, without a note
+	c := DefaultConfig()
+	c.ProjectName, c.Target = "test", "mytest"
+	if got := c.Names().Target; got != "mytest" {
+		t.Errorf("Names().Target = %q, want mytest", got)
+	}
+	if got := c.RenamedTarget(); got != "" {
+		t.Errorf("RenamedTarget with -target = %q, want none", got)
+	}
+}
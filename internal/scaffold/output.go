@@ -0,0 +1,290 @@
+package scaffold
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FS is a writable filesystem a plan can be written to. Paths are
+// slash-separated and relative to the project root.
+type FS interface {
+	MkdirAll(dir string) error
+	WriteFile(name string, data []byte) error
+}
+
+// WriteFS writes every directory and file of the plan to fsys
+func WriteFS(fsys FS, plan *Plan) error {
+	for _, dir := range plan.Dirs {
+		if err := fsys.MkdirAll(dir); err != nil {
+			return err
+		}
+	}
+	for _, filename := range plan.Paths() {
+		if err := fsys.WriteFile(filename, []byte(plan.Files[filename])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DirFS writes below a directory on disk, overwriting existing files
+type DirFS string
+
+func (d DirFS) MkdirAll(dir string) error {
+	p := filepath.Join(string(d), filepath.FromSlash(dir))
+	if err := os.MkdirAll(p, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+	return nil
+}
+
+func (d DirFS) WriteFile(name string, data []byte) error {
+	p := filepath.Join(string(d), filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", name, err)
+	}
+	if err := os.WriteFile(p, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// MemFS keeps written files in memory
+type MemFS struct {
+	Dirs  map[string]bool
+	Files map[string][]byte
+}
+
+// NewMemFS returns an empty in-memory filesystem
+func NewMemFS() *MemFS {
+	return &MemFS{Dirs: make(map[string]bool), Files: make(map[string][]byte)}
+}
+
+func (m *MemFS) MkdirAll(dir string) error {
+	for d := path.Clean(dir); d != "." && d != "/"; d = path.Dir(d) {
+		m.Dirs[d] = true
+	}
+	return nil
+}
+
+func (m *MemFS) WriteFile(name string, data []byte) error {
+	m.MkdirAll(path.Dir(name))
+	m.Files[path.Clean(name)] = append([]byte(nil), data...)
+	return nil
+}
+
+// archiveEntries tracks which directories of an archive were written, so
+// every directory gets exactly one entry before the files inside it
+type archiveEntries struct {
+	root string // prefix of every entry, e.g. the project name
+	dirs map[string]bool
+}
+
+// missingDirs returns the entry names of dir and its parents that were not
+// written yet, outermost first
+func (a *archiveEntries) missingDirs(dir string) []string {
+	var missing []string
+	for d := path.Join(a.root, dir); d != "." && d != "/" && !a.dirs[d]; d = path.Dir(d) {
+		a.dirs[d] = true
+		missing = append(missing, d+"/")
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// TarGzFS streams written files into a gzip-compressed tar archive. Close
+// must be called to finish the archive; it does not close the underlying
+// writer.
+type TarGzFS struct {
+	entries archiveEntries
+	gz      *gzip.Writer
+	tw      *tar.Writer
+	modTime time.Time
+}
+
+// NewTarGzFS returns a TarGzFS writing to w with every entry below root,
+// which may be empty
+func NewTarGzFS(w io.Writer, root string) *TarGzFS {
+	gz := gzip.NewWriter(w)
+	return &TarGzFS{
+		entries: archiveEntries{root: root, dirs: make(map[string]bool)},
+		gz:      gz,
+		tw:      tar.NewWriter(gz),
+		modTime: time.Now(),
+	}
+}
+
+func (t *TarGzFS) MkdirAll(dir string) error {
+	for _, name := range t.entries.missingDirs(dir) {
+		hdr := &tar.Header{Typeflag: tar.TypeDir, Name: name, Mode: 0755, ModTime: t.modTime}
+		if err := t.tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("failed to write archive entry %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (t *TarGzFS) WriteFile(name string, data []byte) error {
+	if err := t.MkdirAll(path.Dir(name)); err != nil {
+		return err
+	}
+	hdr := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     path.Join(t.entries.root, name),
+		Mode:     0644,
+		Size:     int64(len(data)),
+		ModTime:  t.modTime,
+	}
+	if err := t.tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("failed to write archive entry %s: %w", name, err)
+	}
+	if _, err := t.tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write archive entry %s: %w", name, err)
+	}
+	return nil
+}
+
+// Close finishes the archive
+func (t *TarGzFS) Close() error {
+	if err := t.tw.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+	if err := t.gz.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return nil
+}
+
+// ZipFS streams written files into a zip archive. Close must be called to
+// finish the archive; it does not close the underlying writer.
+type ZipFS struct {
+	entries archiveEntries
+	zw      *zip.Writer
+	modTime time.Time
+}
+
+// NewZipFS returns a ZipFS writing to w with every entry below root, which
+// may be empty
+func NewZipFS(w io.Writer, root string) *ZipFS {
+	return &ZipFS{
+		entries: archiveEntries{root: root, dirs: make(map[string]bool)},
+		zw:      zip.NewWriter(w),
+		modTime: time.Now(),
+	}
+}
+
+func (z *ZipFS) MkdirAll(dir string) error {
+	for _, name := range z.entries.missingDirs(dir) {
+		hdr := &zip.FileHeader{Name: name, Modified: z.modTime}
+		hdr.SetMode(0755 | os.ModeDir)
+		if _, err := z.zw.CreateHeader(hdr); err != nil {
+			return fmt.Errorf("failed to write archive entry %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (z *ZipFS) WriteFile(name string, data []byte) error {
+	if err := z.MkdirAll(path.Dir(name)); err != nil {
+		return err
+	}
+	hdr := &zip.FileHeader{Name: path.Join(z.entries.root, name), Method: zip.Deflate, Modified: z.modTime}
+	hdr.SetMode(0644)
+	w, err := z.zw.CreateHeader(hdr)
+	if err != nil {
+		return fmt.Errorf("failed to write archive entry %s: %w", name, err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to write archive entry %s: %w", name, err)
+	}
+	return nil
+}
+
+// Close finishes the archive
+func (z *ZipFS) Close() error {
+	if err := z.zw.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return nil
+}
+
+// ArchiveFormat returns "zip" or "tar.gz" for an output file name, or ""
+// if it is not an archive
+func ArchiveFormat(name string) string {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".zip"):
+		return "zip"
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return "tar.gz"
+	}
+	return ""
+}
+
+// WriteArchive writes the plan to w as a "zip" or "tar.gz" archive with
+// every entry below root
+func WriteArchive(w io.Writer, format, root string, plan *Plan) error {
+	var fsys interface {
+		FS
+		io.Closer
+	}
+	switch format {
+	case "zip":
+		fsys = NewZipFS(w, root)
+	case "tar.gz":
+		fsys = NewTarGzFS(w, root)
+	default:
+		return fmt.Errorf("unknown archive format %q (valid formats: zip, tar.gz)", format)
+	}
+	if err := WriteFS(fsys, plan); err != nil {
+		return err
+	}
+	return fsys.Close()
+}
+
+// SaveArchive writes the plan to an archive file whose format follows from
+// its extension. The archive is written to a temporary file first, so a
+// failure never leaves a truncated archive behind.
+func SaveArchive(filename, root string, plan *Plan, overwrite bool) error {
+	format := ArchiveFormat(filename)
+	if format == "" {
+		return fmt.Errorf("%s is not a .zip, .tar.gz or .tgz file", filename)
+	}
+	if _, err := os.Stat(filename); err == nil && !overwrite {
+		return fmt.Errorf("%s already exists; re-run with -force to overwrite it", filename)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(filename), ".cppinit-archive-*")
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", filename, err)
+	}
+	defer os.Remove(f.Name())
+	if err := WriteArchive(f, format, root, plan); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filename, err)
+	}
+	if err := os.Chmod(f.Name(), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filename, err)
+	}
+	if err := os.Rename(f.Name(), filename); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filename, err)
+	}
+	return nil
+}
+
+// PrintArchived reports a project written to an archive file
+func PrintArchived(filename string, plan *Plan) {
+	fmt.Println(successStyle.Render(fmt.Sprintf("✓ Wrote %d files to %s", len(plan.Files), filename)))
+}
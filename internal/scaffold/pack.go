@@ -0,0 +1,399 @@
+package scaffold
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nikitalobanov12/cppinit/internal/templates"
+	"gopkg.in/yaml.v3"
+)
+
+// PackFile is the manifest at the root of every template pack
+const PackFile = "pack.yaml"
+
+// PackSchemaVersion is the version of the pack manifest format
+const PackSchemaVersion = 1
+
+// Pack option types
+const (
+	PackOptionString = "string"
+	PackOptionBool   = "bool"
+	PackOptionSelect = "select"
+)
+
+// Pack is a template pack: an alternative project archetype. A pack is a
+// directory holding
+//
+//	pack.yaml    name, description, options and file conditions
+//	templates/   templates that replace built-in ones, named like them
+//	files/       extra files; names and contents are templates, and a
+//	             .tmpl suffix is dropped from the output path
+type Pack struct {
+	Schema      int    `yaml:"schema" json:"schema"`
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	Version     string `yaml:"version,omitempty" json:"version,omitempty"`
+
+	// Builtin includes cppinit's standard project files; packs that define
+	// a completely different layout set it to false
+	Builtin *bool `yaml:"builtin,omitempty" json:"builtin,omitempty"`
+
+	// Options are asked for in the wizard and available to templates as
+	// {{ .Pack.<name> }}
+	Options []PackOption `yaml:"options,omitempty" json:"options,omitempty"`
+
+	// Files lists conditions for files below files/
+	Files []PackFileRule `yaml:"files,omitempty" json:"files,omitempty"`
+
+	// Dir is where the pack was loaded from
+	Dir string `yaml:"-" json:"dir"`
+}
+
+// PackOption is one question a pack asks
+type PackOption struct {
+	Name        string   `yaml:"name" json:"name"`
+	Prompt      string   `yaml:"prompt,omitempty" json:"prompt,omitempty"`
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Type        string   `yaml:"type,omitempty" json:"type,omitempty"` // string (default), bool or select
+	Default     any      `yaml:"default,omitempty" json:"default,omitempty"`
+	Choices     []string `yaml:"choices,omitempty" json:"choices,omitempty"` // for select
+}
+
+// PackFileRule generates the files matching Path (a path.Match pattern
+// relative to files/, or a directory ending in "/") only when the When
+// template renders to "true"
+type PackFileRule struct {
+	Path string `yaml:"path" json:"path"`
+	When string `yaml:"when" json:"when"`
+}
+
+// PacksDir returns the directory packs are installed to,
+// ~/.config/cppinit/packs on Linux
+func PacksDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate the config directory: %w", err)
+	}
+	return filepath.Join(dir, "cppinit", "packs"), nil
+}
+
+// LoadPack reads and checks the pack in dir
+func LoadPack(dir string) (*Pack, error) {
+	data, err := os.ReadFile(filepath.Join(dir, PackFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", PackFile, err)
+	}
+
+	var p Pack
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&p); err != nil {
+		return nil, fmt.Errorf("failed to parse %s in %s: %w", PackFile, dir, err)
+	}
+	p.Dir = dir
+
+	if err := p.check(); err != nil {
+		return nil, fmt.Errorf("invalid pack %s: %w", dir, err)
+	}
+	return &p, nil
+}
+
+// check validates the manifest of a pack
+func (p *Pack) check() error {
+	switch {
+	case p.Schema == 0:
+		return fmt.Errorf("%s has no schema version; add \"schema: %d\"", PackFile, PackSchemaVersion)
+	case p.Schema > PackSchemaVersion:
+		return fmt.Errorf("%s uses schema %d, but this cppinit only understands schema %d; please upgrade cppinit",
+			PackFile, p.Schema, PackSchemaVersion)
+	case p.Name == "":
+		return fmt.Errorf("%s has no name", PackFile)
+	case strings.ContainsAny(p.Name, `/\:*?"<>| `) || strings.HasPrefix(p.Name, "."):
+		return fmt.Errorf("pack name %q must be a plain directory name", p.Name)
+	}
+
+	seen := make(map[string]bool)
+	for i := range p.Options {
+		o := &p.Options[i]
+		if o.Name == "" {
+			return fmt.Errorf("option %d has no name", i+1)
+		}
+		if seen[o.Name] {
+			return fmt.Errorf("option %s is defined twice", o.Name)
+		}
+		seen[o.Name] = true
+
+		if o.Type == "" {
+			o.Type = PackOptionString
+		}
+		switch o.Type {
+		case PackOptionString, PackOptionBool:
+		case PackOptionSelect:
+			if len(o.Choices) == 0 {
+				return fmt.Errorf("select option %s has no choices", o.Name)
+			}
+		default:
+			return fmt.Errorf("option %s has unknown type %q (%s)", o.Name, o.Type,
+				suggest(o.Type, []string{PackOptionString, PackOptionBool, PackOptionSelect}))
+		}
+		if o.Default != nil {
+			if _, err := o.convert(o.Default); err != nil {
+				return fmt.Errorf("default of option %s: %w", o.Name, err)
+			}
+		}
+	}
+
+	for _, rule := range p.Files {
+		if _, err := path.Match(strings.TrimSuffix(rule.Path, "/"), ""); err != nil {
+			return fmt.Errorf("bad file pattern %q: %w", rule.Path, err)
+		}
+	}
+	return nil
+}
+
+// IncludesBuiltin reports whether cppinit's standard files are generated
+// alongside the pack's own
+func (p *Pack) IncludesBuiltin() bool {
+	return p.Builtin == nil || *p.Builtin
+}
+
+// FindPack loads a pack by installed name, or from a directory path
+func FindPack(nameOrPath string) (*Pack, error) {
+	if info, err := os.Stat(nameOrPath); err == nil && info.IsDir() &&
+		(strings.ContainsAny(nameOrPath, `/\`) || nameOrPath == ".") {
+		return LoadPack(nameOrPath)
+	}
+
+	packsDir, err := PacksDir()
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(packsDir, nameOrPath)
+	if _, err := os.Stat(filepath.Join(dir, PackFile)); errors.Is(err, fs.ErrNotExist) {
+		installed, _ := InstalledPacks()
+		names := make([]string, 0, len(installed))
+		for _, p := range installed {
+			names = append(names, p.Name)
+		}
+		if len(names) == 0 {
+			return nil, fmt.Errorf("pack %q is not installed; install it with \"cppinit pack install\"", nameOrPath)
+		}
+		return nil, fmt.Errorf("pack %q is not installed (%s)", nameOrPath, suggest(nameOrPath, names))
+	}
+	return LoadPack(dir)
+}
+
+// InstalledPacks lists the installed packs sorted by name. Directories
+// that do not hold a valid pack are skipped.
+func InstalledPacks() ([]*Pack, error) {
+	packsDir, err := PacksDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(packsDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list packs: %w", err)
+	}
+
+	var packs []*Pack
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		if p, err := LoadPack(filepath.Join(packsDir, entry.Name())); err == nil {
+			packs = append(packs, p)
+		}
+	}
+	sort.Slice(packs, func(i, j int) bool { return packs[i].Name < packs[j].Name })
+	return packs, nil
+}
+
+// convert checks a value against the option's type. Strings are parsed, so
+// values from the command line can be given as text.
+func (o *PackOption) convert(value any) (any, error) {
+	switch o.Type {
+	case PackOptionBool:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not true or false", v)
+			}
+			return b, nil
+		}
+		return nil, fmt.Errorf("%v is not true or false", value)
+
+	case PackOptionSelect:
+		s := fmt.Sprint(value)
+		if !slices.Contains(o.Choices, s) {
+			return nil, fmt.Errorf("unknown choice %q (%s)", s, suggest(s, o.Choices))
+		}
+		return s, nil
+
+	default:
+		return fmt.Sprint(value), nil
+	}
+}
+
+// zero is the value of an option without a default
+func (o *PackOption) zero() any {
+	switch o.Type {
+	case PackOptionBool:
+		return false
+	case PackOptionSelect:
+		return o.Choices[0]
+	default:
+		return ""
+	}
+}
+
+// ResolveOptions checks values against the pack's options and fills in
+// defaults for the ones left out
+func (p *Pack) ResolveOptions(values map[string]any) (map[string]any, error) {
+	var problems []Problem
+	resolved := make(map[string]any, len(p.Options))
+	names := make([]string, 0, len(p.Options))
+	for i := range p.Options {
+		o := &p.Options[i]
+		names = append(names, o.Name)
+
+		value, ok := values[o.Name]
+		if !ok {
+			value = o.Default
+			if value == nil {
+				value = o.zero()
+			}
+		}
+		v, err := o.convert(value)
+		if err != nil {
+			problems = append(problems, Problem{Field: "pack_options." + o.Name, Message: err.Error()})
+			continue
+		}
+		resolved[o.Name] = v
+	}
+
+	for name := range values {
+		if !slices.Contains(names, name) {
+			problems = append(problems, Problem{
+				Field:      "pack_options." + name,
+				Message:    fmt.Sprintf("pack %s has no option %q", p.Name, name),
+				Suggestion: suggest(name, names),
+			})
+		}
+	}
+	if len(problems) > 0 {
+		sort.Slice(problems, func(i, j int) bool { return problems[i].Field < problems[j].Field })
+		return nil, &ValidationError{Problems: problems}
+	}
+	return resolved, nil
+}
+
+// matches reports whether a rule applies to a path below files/
+func (r PackFileRule) matches(name string) bool {
+	if dir, ok := strings.CutSuffix(r.Path, "/"); ok {
+		return strings.HasPrefix(name, dir+"/")
+	}
+	ok, _ := path.Match(r.Path, name)
+	return ok
+}
+
+// renderFiles renders the pack's files/ tree into files, skipping files
+// whose conditions are false
+func (p *Pack) renderFiles(data *templateData, files map[string]string) error {
+	root := filepath.Join(p.Dir, "files")
+	if _, err := os.Stat(root); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	return filepath.WalkDir(root, func(filename string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(root, filename)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+
+		for _, rule := range p.Files {
+			if !rule.matches(name) {
+				continue
+			}
+			when, err := templates.RenderText(p.Name+": "+rule.Path, rule.When, data)
+			if err != nil {
+				return fmt.Errorf("failed to evaluate condition for %s: %w", name, err)
+			}
+			if strings.TrimSpace(when) != "true" {
+				return nil
+			}
+		}
+
+		text, err := os.ReadFile(filename)
+		if err != nil {
+			return fmt.Errorf("failed to read pack file %s: %w", name, err)
+		}
+		content := string(text)
+		if strings.HasSuffix(name, templates.Ext) {
+			if content, err = templates.RenderText(p.Name+": "+name, content, data); err != nil {
+				return fmt.Errorf("failed to render %s: %w", name, err)
+			}
+		}
+		target, err := templates.RenderText(p.Name+": path of "+name, strings.TrimSuffix(name, templates.Ext), data)
+		if err != nil {
+			return fmt.Errorf("failed to render the path of %s: %w", name, err)
+		}
+		target = path.Clean(target)
+		if path.IsAbs(target) || target == ".." || strings.HasPrefix(target, "../") {
+			return fmt.Errorf("pack file %s would be written outside the project (%s)", name, target)
+		}
+		files[target] = content
+		return nil
+	})
+}
+
+// PrintPackInstalled reports a freshly installed pack
+func PrintPackInstalled(p *Pack) {
+	fmt.Println(successStyle.Render(fmt.Sprintf("✓ Installed pack %s", p.Name)))
+	fmt.Printf("  %s\n", pathStyle.Render(p.Dir))
+	fmt.Println()
+	fmt.Println("Generate a project from it with:")
+	fmt.Printf("  cppinit new <name> -pack %s\n", p.Name)
+}
+
+// PrintPacks lists installed packs with their descriptions
+func PrintPacks(packs []*Pack) {
+	if len(packs) == 0 {
+		fmt.Println(dimStyle.Render("No template packs installed; add one with \"cppinit pack install\""))
+		return
+	}
+
+	width := 0
+	for _, p := range packs {
+		width = max(width, len(p.Name))
+	}
+	fmt.Println(titleStyle.Render("Installed template packs"))
+	for _, p := range packs {
+		line := fmt.Sprintf("  %-*s", width, p.Name)
+		if p.Version != "" {
+			line += "  " + dimStyle.Render(p.Version)
+		}
+		if p.Description != "" {
+			line += "  " + p.Description
+		}
+		fmt.Println(line)
+	}
+}
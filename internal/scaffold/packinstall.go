@@ -0,0 +1,277 @@
+package scaffold
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// maxPackSize caps downloads and the unpacked size of pack archives
+const maxPackSize = 64 << 20
+
+// InstallPack installs a pack from a directory, a local .tar.gz or an
+// http(s) URL of a .tar.gz into PacksDir. Downloads must come with their
+// sha256 checksum; for local archives it is optional but still verified.
+// An installed pack of the same name is only replaced when force is set.
+func InstallPack(source, checksum string, force bool) (*Pack, error) {
+	packsDir, err := PacksDir()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(packsDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory %s: %w", packsDir, err)
+	}
+
+	staging, err := os.MkdirTemp(packsDir, ".cppinit-pack-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	defer os.RemoveAll(staging)
+
+	isURL := strings.HasPrefix(source, "https://") || strings.HasPrefix(source, "http://")
+	if info, err := os.Stat(source); !isURL && err == nil && info.IsDir() {
+		if checksum != "" {
+			return nil, fmt.Errorf("-sha256 only applies to archives, %s is a directory", source)
+		}
+		if err := copyDir(source, staging); err != nil {
+			return nil, err
+		}
+	} else {
+		if isURL && checksum == "" {
+			return nil, fmt.Errorf("downloaded packs need a checksum; pass -sha256 with the archive's sha256")
+		}
+		archive, err := fetchPack(source, isURL, staging)
+		if err != nil {
+			return nil, err
+		}
+		if err := verifyChecksum(archive, checksum); err != nil {
+			return nil, err
+		}
+		if err := extractTarGz(archive, filepath.Join(staging, "pack")); err != nil {
+			return nil, fmt.Errorf("failed to unpack %s: %w", source, err)
+		}
+		staging = filepath.Join(staging, "pack")
+	}
+
+	root, err := packRoot(staging)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a template pack: %w", source, err)
+	}
+	pack, err := LoadPack(root)
+	if err != nil {
+		return nil, err
+	}
+
+	target := filepath.Join(packsDir, pack.Name)
+	if _, err := os.Stat(target); err == nil {
+		if !force {
+			return nil, fmt.Errorf("pack %s is already installed; use -force to replace it", pack.Name)
+		}
+		if err := os.RemoveAll(target); err != nil {
+			return nil, fmt.Errorf("failed to remove the installed pack %s: %w", pack.Name, err)
+		}
+	}
+	// MkdirTemp creates the staging directory private to the user
+	if err := os.Chmod(root, 0755); err != nil {
+		return nil, fmt.Errorf("failed to make pack %s readable: %w", pack.Name, err)
+	}
+	if err := os.Rename(root, target); err != nil {
+		return nil, fmt.Errorf("failed to move pack into place: %w", err)
+	}
+	pack.Dir = target
+	return pack, nil
+}
+
+// fetchPack makes the archive at source available as a local file, which
+// for URLs is a download into dir
+func fetchPack(source string, isURL bool, dir string) (string, error) {
+	if !isURL {
+		if _, err := os.Stat(source); err != nil {
+			return "", fmt.Errorf("failed to read pack %s: %w", source, err)
+		}
+		return source, nil
+	}
+
+	client := &http.Client{Timeout: 2 * time.Minute}
+	resp, err := client.Get(source)
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", source, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download %s: %s", source, resp.Status)
+	}
+
+	archive := filepath.Join(dir, "pack.tar.gz")
+	f, err := os.Create(archive)
+	if err != nil {
+		return "", fmt.Errorf("failed to save download: %w", err)
+	}
+	defer f.Close()
+	n, err := io.Copy(f, io.LimitReader(resp.Body, maxPackSize+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", source, err)
+	}
+	if n > maxPackSize {
+		return "", fmt.Errorf("%s is larger than %d MiB", source, maxPackSize>>20)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("failed to save download: %w", err)
+	}
+	return archive, nil
+}
+
+// verifyChecksum compares the sha256 of a file with the expected hex digest,
+// if one was given
+func verifyChecksum(filename, expected string) error {
+	if expected == "" {
+		return nil
+	}
+	expected = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(expected), "sha256:"))
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", filename, err)
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return fmt.Errorf("failed to read %s: %w", filename, err)
+	}
+	if actual := hex.EncodeToString(h.Sum(nil)); actual != expected {
+		return fmt.Errorf("checksum mismatch: expected sha256 %s, got %s", expected, actual)
+	}
+	return nil
+}
+
+// extractTarGz unpacks regular files and directories of a .tar.gz into dir,
+// refusing entries that would land outside it
+func extractTarGz(archive, dir string) error {
+	f, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	var total int64
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := path.Clean(strings.TrimPrefix(hdr.Name, "./"))
+		if name == "." {
+			continue
+		}
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return fmt.Errorf("archive entry %s points outside the pack", hdr.Name)
+		}
+		target := filepath.Join(dir, filepath.FromSlash(name))
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			total += hdr.Size
+			if total > maxPackSize {
+				return fmt.Errorf("unpacked size exceeds %d MiB", maxPackSize>>20)
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			if err := writeFrom(target, io.LimitReader(tr, hdr.Size)); err != nil {
+				return err
+			}
+		default:
+			// Links and devices have no place in a template pack
+			return fmt.Errorf("archive entry %s is not a regular file or directory", hdr.Name)
+		}
+	}
+}
+
+// writeFrom creates filename with the contents of r
+func writeFrom(filename string, r io.Reader) error {
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// copyDir copies the regular files below src into dst
+func copyDir(src, dst string) error {
+	return filepath.WalkDir(src, func(filename string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, filename)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return os.MkdirAll(target, 0755)
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(filename)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", filename, err)
+		}
+		defer f.Close()
+		if err := writeFrom(target, f); err != nil {
+			return fmt.Errorf("failed to copy %s: %w", filename, err)
+		}
+		return nil
+	})
+}
+
+// packRoot finds pack.yaml at the top of dir or inside its only
+// subdirectory, as archives usually wrap their contents in one
+func packRoot(dir string) (string, error) {
+	if _, err := os.Stat(filepath.Join(dir, PackFile)); err == nil {
+		return dir, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	if len(entries) == 1 && entries[0].IsDir() {
+		sub := filepath.Join(dir, entries[0].Name())
+		if _, err := os.Stat(filepath.Join(sub, PackFile)); err == nil {
+			return sub, nil
+		}
+	}
+	return "", fmt.Errorf("no %s found", PackFile)
+}
@@ -0,0 +1,278 @@
+package scaffold
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Preset is a named partial config: the values it sets are laid over the
+// user defaults before the config file and flags are applied. Values use the
+// keys of the defaults file; standard and tests may also be given per
+// language as standard_c, standard_cpp, tests_c and tests_cpp.
+type Preset struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Extends     string            `json:"extends,omitempty"`
+	Values      map[string]string `json:"values"`
+	Path        string            `json:"path,omitempty"` // file of a user preset, empty for built-ins
+}
+
+// BuiltinPresets are the presets every cppinit knows
+var BuiltinPresets = []*Preset{
+	{
+		Name:        "minimal",
+		Description: "No extra tooling, just the build",
+		Values: map[string]string{
+			"clang_format": "false", "clang_tidy": "false", "sanitizers": "false", "coverage": "false",
+			"doxygen": "false", "docker": "false", "precommit": "false", "ci": "false", "vscode": "false",
+		},
+	},
+	{
+		Name:        "ci-strict",
+		Description: "Tests, sanitizers, coverage, formatting and linting enforced in CI",
+		Values: map[string]string{
+			"tests_c": "unity", "tests_cpp": "googletest",
+			"clang_format": "true", "clang_tidy": "true", "sanitizers": "true", "coverage": "true",
+			"precommit": "true", "ci": "true",
+		},
+	},
+	{
+		Name:        "full",
+		Description: "Every feature: ci-strict plus Doxygen, Docker and VSCode",
+		Extends:     "ci-strict",
+		Values:      map[string]string{"doxygen": "true", "docker": "true", "vscode": "true"},
+	},
+	{
+		Name:        "library-publishing",
+		Description: "Static library with strict CI and API documentation",
+		Extends:     "ci-strict",
+		Values:      map[string]string{"type": "static", "doxygen": "true"},
+	},
+	{
+		Name:        "embedded",
+		Description: "C11 static library without host-only tooling",
+		Values: map[string]string{
+			"language": "c", "standard_c": "11", "type": "static", "tests_c": "unity", "package_manager": "none",
+			"sanitizers": "false", "coverage": "false", "docker": "false", "vscode": "false",
+		},
+	},
+}
+
+// PresetsDir returns the directory of user presets,
+// ~/.config/cppinit/presets on Linux
+func PresetsDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate the config directory: %w", err)
+	}
+	return filepath.Join(dir, "cppinit", "presets"), nil
+}
+
+// LoadPreset reads a user preset from a YAML file named after the preset
+func LoadPreset(path string) (*Preset, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read preset: %w", err)
+	}
+	values := map[string]string{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse preset %s: %w", path, err)
+	}
+
+	p := &Preset{
+		Name:        strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+		Description: values["description"],
+		Extends:     values["extends"],
+		Path:        path,
+	}
+	delete(values, "description")
+	delete(values, "extends")
+	delete(values, "schema")
+	p.Values = values
+	if err := p.check(); err != nil {
+		return nil, fmt.Errorf("preset %s: %w", path, err)
+	}
+	return p, nil
+}
+
+// check validates every value of the preset
+func (p *Preset) check() error {
+	for key, value := range p.Values {
+		base, _ := presetKey(key)
+		s, err := findSetting(base)
+		if err != nil {
+			return err
+		}
+		if err := s.set(DefaultConfig(), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// presetKey splits a language specific key such as tests_cpp into the
+// config key and the language it applies to, "" for every language
+func presetKey(key string) (base, language string) {
+	if base, ok := strings.CutSuffix(key, "_cpp"); ok {
+		return base, "c++"
+	}
+	if base, ok := strings.CutSuffix(key, "_c"); ok && (base == "standard" || base == "tests") {
+		return base, "c"
+	}
+	return key, ""
+}
+
+// Presets returns the built-in presets followed by the user presets
+func Presets() ([]*Preset, error) {
+	presets := append([]*Preset(nil), BuiltinPresets...)
+
+	dir, err := PresetsDir()
+	if err != nil {
+		return presets, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return presets, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read presets: %w", err)
+	}
+
+	var user []*Preset
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		if e.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		p, err := LoadPreset(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range BuiltinPresets {
+			if b.Name == p.Name {
+				return nil, fmt.Errorf("preset %s: %s is a built-in preset; pick another name", p.Path, p.Name)
+			}
+		}
+		user = append(user, p)
+	}
+	sort.Slice(user, func(i, j int) bool { return user[i].Name < user[j].Name })
+	return append(presets, user...), nil
+}
+
+// FindPreset looks up a built-in or user preset by name
+func FindPreset(name string) (*Preset, error) {
+	presets, err := Presets()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(presets))
+	for _, p := range presets {
+		if p.Name == name {
+			return p, nil
+		}
+		names = append(names, p.Name)
+	}
+	return nil, fmt.Errorf("unknown preset %q (%s)", name, suggest(name, names))
+}
+
+// Resolve returns the values of the preset merged over the presets it
+// extends
+func (p *Preset) Resolve() (map[string]string, error) {
+	values := map[string]string{}
+	seen := map[string]bool{}
+	var chain []*Preset
+	for cur := p; cur != nil; {
+		if seen[cur.Name] {
+			return nil, fmt.Errorf("preset %s extends itself through %s", p.Name, cur.Name)
+		}
+		seen[cur.Name] = true
+		chain = append(chain, cur)
+
+		if cur.Extends == "" {
+			break
+		}
+		parent, err := FindPreset(cur.Extends)
+		if err != nil {
+			return nil, fmt.Errorf("preset %s: %w", cur.Name, err)
+		}
+		cur = parent
+	}
+
+	// Outermost parent first, so that extending presets win
+	for i := len(chain) - 1; i >= 0; i-- {
+		for key, value := range chain[i].Values {
+			values[key] = value
+		}
+	}
+	return values, nil
+}
+
+// Apply lays the preset over c, leaving alone every key for which skip
+// returns true. Per-language values only apply to projects of their
+// language. When the preset switches the language without naming a
+// standard, the standard is cleared so that it follows the new language.
+func (p *Preset) Apply(c *Config, skip func(key string) bool) error {
+	values, err := p.Resolve()
+	if err != nil {
+		return err
+	}
+	if skip == nil {
+		skip = func(string) bool { return false }
+	}
+
+	// A plain standard belongs to the preset's language
+	language, setsLanguage := values["language"]
+	if setsLanguage && skip("language") && language != c.Language {
+		delete(values, "standard")
+	}
+	if setsLanguage && !skip("language") && language != c.Language {
+		c.Language = language
+		if !skip("standard") {
+			c.Standard = ""
+		}
+	}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys) // plain keys before their _c and _cpp variants
+
+	for _, key := range keys {
+		base, language := presetKey(key)
+		if base == "language" || skip(base) || (language != "" && language != c.Language) {
+			continue
+		}
+		s, err := findSetting(base)
+		if err != nil {
+			return err
+		}
+		if err := s.set(c, values[key]); err != nil {
+			return fmt.Errorf("preset %s: %w", p.Name, err)
+		}
+	}
+	return nil
+}
+
+// PrintPresets lists the built-in and user presets
+func PrintPresets(presets []*Preset) {
+	width := 0
+	for _, p := range presets {
+		width = max(width, len(p.Name))
+	}
+	fmt.Println(titleStyle.Render("Presets (-preset)"))
+	for _, p := range presets {
+		line := fmt.Sprintf("  %-*s  %s", width, p.Name, p.Description)
+		if p.Path != "" {
+			line += dimStyle.Render("  (" + p.Path + ")")
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+}
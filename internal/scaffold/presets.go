@@ -0,0 +1,200 @@
+package scaffold
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// presetKinds are the preset arrays of CMakePresets.json
+var presetKinds = []string{"configurePresets", "buildPresets", "testPresets"}
+
+// jsonSpan is the byte range of a value in a JSON document
+type jsonSpan struct {
+	start, end int
+}
+
+// presetArray is one preset array of a CMakePresets.json with the byte
+// ranges of the array, from [ to ], and of each preset
+type presetArray struct {
+	span    jsonSpan
+	presets []jsonSpan
+	names   []string
+}
+
+// scanPresets locates the preset arrays of a CMakePresets.json by kind and
+// returns the offset of the closing brace of the top-level object
+func scanPresets(data []byte) (map[string]*presetArray, int, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, 0, fmt.Errorf("expected a JSON object")
+	}
+
+	arrays := make(map[string]*presetArray)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, 0, err
+		}
+		key, _ := tok.(string)
+		if !slices.Contains(presetKinds, key) {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return nil, 0, err
+			}
+			continue
+		}
+
+		if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+			return nil, 0, fmt.Errorf("%s must be an array", key)
+		}
+		a := &presetArray{span: jsonSpan{start: int(dec.InputOffset()) - 1}}
+		for dec.More() {
+			// The decoder has not consumed the comma in front of the preset yet
+			start := int(dec.InputOffset())
+			for start < len(data) && strings.ContainsRune(" \t\r\n,", rune(data[start])) {
+				start++
+			}
+			var preset json.RawMessage
+			if err := dec.Decode(&preset); err != nil {
+				return nil, 0, fmt.Errorf("%s: %w", key, err)
+			}
+			a.presets = append(a.presets, jsonSpan{start, int(dec.InputOffset())})
+			a.names = append(a.names, presetName(preset))
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, 0, err
+		}
+		a.span.end = int(dec.InputOffset())
+		arrays[key] = a
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, 0, err
+	}
+	return arrays, int(dec.InputOffset()) - 1, nil
+}
+
+// presetName returns the "name" member of a preset
+func presetName(preset json.RawMessage) string {
+	var p struct {
+		Name string `json:"name"`
+	}
+	json.Unmarshal(preset, &p)
+	return p.Name
+}
+
+// lineIndent returns the leading whitespace of the line containing offset
+func lineIndent(data []byte, offset int) string {
+	start := bytes.LastIndexByte(data[:offset], '\n') + 1
+	end := start
+	for end < len(data) && (data[end] == ' ' || data[end] == '\t') {
+		end++
+	}
+	return string(data[start:end])
+}
+
+// textEdit replaces the bytes from start to end of a document with text
+type textEdit struct {
+	span jsonSpan
+	text string
+}
+
+// syncPresets applies the preset changes between two renders of
+// CMakePresets.json to the current file: presets only in after are appended
+// and presets only in before are dropped. Only the preset arrays that change
+// are rewritten; everything else in the file, including its formatting and
+// presets added by hand, is kept byte for byte.
+func syncPresets(current, before, after string) (string, error) {
+	data := []byte(current)
+	arrays, end, err := scanPresets(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse CMakePresets.json: %w", err)
+	}
+	oldArrays, _, err := scanPresets([]byte(before))
+	if err != nil {
+		return "", err
+	}
+	afterData := []byte(after)
+	newArrays, _, err := scanPresets(afterData)
+	if err != nil {
+		return "", err
+	}
+
+	var edits []textEdit
+	for _, kind := range presetKinds {
+		var oldNames []string
+		if a := oldArrays[kind]; a != nil {
+			oldNames = a.names
+		}
+		next := newArrays[kind]
+		if next == nil {
+			next = &presetArray{}
+		}
+
+		// Presets the feature now produces, as rendered
+		var added []string
+		a := arrays[kind]
+		for i, name := range next.names {
+			if !slices.Contains(oldNames, name) && (a == nil || !slices.Contains(a.names, name)) {
+				added = append(added, after[next.presets[i].start:next.presets[i].end])
+			}
+		}
+		elemIndent := "        "
+		if len(next.presets) > 0 {
+			elemIndent = lineIndent(afterData, next.presets[0].start)
+		}
+
+		if a == nil {
+			if len(added) == 0 {
+				continue
+			}
+			memberIndent := lineIndent(afterData, next.span.start)
+			text := fmt.Sprintf(",\n%s%q: [\n%s%s\n%s]", memberIndent, kind,
+				elemIndent, strings.Join(added, ",\n"+elemIndent), memberIndent)
+			at := bytes.LastIndexFunc(data[:end], func(r rune) bool { return !strings.ContainsRune(" \t\r\n", r) }) + 1
+			edits = append(edits, textEdit{jsonSpan{at, at}, text})
+			continue
+		}
+
+		// Drop presets the feature no longer produces
+		var kept []string
+		for i, name := range a.names {
+			if !slices.Contains(oldNames, name) || slices.Contains(next.names, name) {
+				kept = append(kept, current[a.presets[i].start:a.presets[i].end])
+			}
+		}
+		if len(added) == 0 && len(kept) == len(a.presets) {
+			continue
+		}
+
+		// Keep the array's own layout around and between the presets
+		lead, sep, trail := "\n"+elemIndent, ",\n"+elemIndent, "\n"+lineIndent(data, a.span.start)
+		if n := len(a.presets); n > 0 {
+			indent := lineIndent(data, a.presets[0].start)
+			for i := range added {
+				added[i] = strings.ReplaceAll(added[i], "\n"+elemIndent, "\n"+indent)
+			}
+			lead = current[a.span.start+1 : a.presets[0].start]
+			trail = current[a.presets[n-1].end : a.span.end-1]
+			if n > 1 {
+				sep = current[a.presets[0].end:a.presets[1].start]
+			}
+		}
+		elems := append(kept, added...)
+		text := "[]"
+		if len(elems) > 0 {
+			text = "[" + lead + strings.Join(elems, sep) + trail + "]"
+		}
+		edits = append(edits, textEdit{a.span, text})
+	}
+
+	// Back to front, so that earlier offsets stay valid
+	sort.Slice(edits, func(i, j int) bool { return edits[i].span.start > edits[j].span.start })
+	for _, e := range edits {
+		current = current[:e.span.start] + e.text + current[e.span.end:]
+	}
+	return current, nil
+}
@@ -5,10 +5,12 @@ import (
 	"os"
 	"os/user"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/nikitalobanov12/cppinit/internal/templates"
 )
 
 var (
@@ -32,9 +34,46 @@ var (
 			Foreground(lipgloss.Color("241"))
 )
 
-// RunPrompts runs the interactive prompts and returns the configuration
-func RunPrompts() (*Config, error) {
+// RunPrompts runs the interactive prompts with the values of defaults
+// pre-selected and returns the configuration. The first page picks one of
+// presets, presetName unless the user changes it.
+func RunPrompts(defaults *Config, presets []*Preset, presetName string) (*Config, error) {
 	config := &Config{}
+	*config = *defaults
+	config.ProjectName = ""
+	config.Description = ""
+
+	// Page 0: Preset Selection, laid over the defaults of every later page
+	presetOptions := []huh.Option[string]{huh.NewOption("None", "")}
+	for _, p := range presets {
+		presetOptions = append(presetOptions, huh.NewOption(p.Name+" - "+p.Description, p.Name))
+	}
+	presetForm := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Preset").
+				Description("Start from a preset; every answer can still be changed").
+				Options(presetOptions...).
+				Value(&presetName),
+		).Title("Preset Selection"),
+	)
+
+	if err := presetForm.Run(); err != nil {
+		return nil, err
+	}
+
+	var preset *Preset
+	for _, p := range presets {
+		if p.Name == presetName {
+			preset = p
+		}
+	}
+	if preset != nil {
+		if err := preset.Apply(config, nil); err != nil {
+			return nil, err
+		}
+	}
+	language := config.Language
 
 	// Get current directory name as default project name
 	cwd, _ := os.Getwd()
@@ -44,7 +83,7 @@ func RunPrompts() (*Config, error) {
 	currentUser, _ := user.Current()
 	defaultAuthor := currentUser.Username
 
-	// Page 0: Language Selection (first question)
+	// Page 0: Language Selection
 	langForm := huh.NewForm(
 		huh.NewGroup(
 			huh.NewSelect[string]().
@@ -62,6 +101,28 @@ func RunPrompts() (*Config, error) {
 		return nil, err
 	}
 
+	// Keep only the defaults that exist for the chosen language
+	standards, frameworks := CppStandards, CppTestFrameworks
+	if config.IsC() {
+		standards, frameworks = CStandards, CTestFrameworks
+	}
+	if config.Language != language || !slices.Contains(standards, config.Standard) {
+		config.Standard = config.DefaultStandard()
+	}
+	if !slices.Contains(frameworks, config.TestFramework) {
+		config.TestFramework = "none"
+	}
+	if config.IsC() && config.ProjectType == "header-only" {
+		config.ProjectType = "executable"
+	}
+	// The preset's values for the chosen language
+	if preset != nil && config.Language != language {
+		skip := func(key string) bool { return key == "language" }
+		if err := preset.Apply(config, skip); err != nil {
+			return nil, err
+		}
+	}
+
 	// Display appropriate title based on language
 	if config.IsC() {
 		fmt.Println(titleStyle.Render("🚀 Create C Project"))
@@ -154,6 +215,62 @@ func RunPrompts() (*Config, error) {
 		return nil, err
 	}
 
+	// Names derived from the project name, changed only on request
+	name := config.ProjectName
+	if name == "" {
+		name = defaultName
+	}
+	derived := DeriveNames(name)
+	namesDescription := fmt.Sprintf("Identifier %s, macro prefix %s, CMake target %s, files %s",
+		derived.Identifier, derived.MacroPrefix, derived.Target, derived.FileStem)
+	if note := (&Config{ProjectName: name}).RenamedTarget(); note != "" {
+		namesDescription += "\n" + note
+	}
+	customizeNames := false
+	namesForm := huh.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title("Change the generated names?").
+				Description(namesDescription).
+				Value(&customizeNames),
+		).Title("Generated Names"),
+	)
+	if err := namesForm.Run(); err != nil {
+		return nil, err
+	}
+	if customizeNames {
+		customForm := huh.NewForm(
+			huh.NewGroup(
+				huh.NewInput().
+					Title("Identifier").
+					Description("Namespace, function prefix and test names").
+					Value(&config.Identifier).
+					Placeholder(derived.Identifier).
+					Validate(validateIdentifier),
+				huh.NewInput().
+					Title("Macro prefix").
+					Description("Include guards and macros").
+					Value(&config.MacroPrefix).
+					Placeholder(derived.MacroPrefix).
+					Validate(validateMacroPrefix),
+				huh.NewInput().
+					Title("CMake target").
+					Value(&config.Target).
+					Placeholder(derived.Target).
+					Validate(validateTarget),
+				huh.NewInput().
+					Title("File stem").
+					Description("Include directory, header and source file names").
+					Value(&config.FileStem).
+					Placeholder(derived.FileStem).
+					Validate(validateFileStem),
+			).Title("Generated Names"),
+		)
+		if err := customForm.Run(); err != nil {
+			return nil, err
+		}
+	}
+
 	// Build test framework options based on language
 	var testFrameworkOptions []huh.Option[string]
 	if config.IsC() {
@@ -171,30 +288,29 @@ func RunPrompts() (*Config, error) {
 	}
 
 	// Page 2: Dependencies & Testing
-	depsForm := huh.NewForm(
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("Package manager").
-				Description("How do you want to manage dependencies?").
-				Options(
-					huh.NewOption("None (FetchContent only)", "none"),
-					huh.NewOption("vcpkg", "vcpkg"),
-					huh.NewOption("Conan", "conan"),
-					huh.NewOption("CPM.cmake", "cpm"),
-				).
-				Value(&config.PackageManager),
+	depsFields := []huh.Field{
+		huh.NewSelect[string]().
+			Title("Package manager").
+			Description("How do you want to manage dependencies?").
+			Options(
+				huh.NewOption("None (FetchContent only)", "none"),
+				huh.NewOption("vcpkg", "vcpkg"),
+				huh.NewOption("Conan", "conan"),
+				huh.NewOption("CPM.cmake", "cpm"),
+			).
+			Value(&config.PackageManager),
+
+		huh.NewSelect[string]().
+			Title("Testing framework").
+			Description("Include a testing framework?").
+			Options(testFrameworkOptions...).
+			Value(&config.TestFramework),
+	}
 
-			huh.NewSelect[string]().
-				Title("Testing framework").
-				Description("Include a testing framework?").
-				Options(testFrameworkOptions...).
-				Value(&config.TestFramework),
+	depsFields = append(depsFields, featureConfirms(config, GroupDependencies)...)
 
-			huh.NewConfirm().
-				Title("Include benchmarks?").
-				Description("Add Google Benchmark for performance testing").
-				Value(&config.IncludeBenchmark),
-		).Title("Dependencies & Testing"),
+	depsForm := huh.NewForm(
+		huh.NewGroup(depsFields...).Title("Dependencies & Testing"),
 	)
 
 	if err := depsForm.Run(); err != nil {
@@ -202,20 +318,17 @@ func RunPrompts() (*Config, error) {
 	}
 
 	// Page 3: Tooling
+	var toolOptions []huh.Option[string]
+	for _, f := range promptedFeatures(config, GroupQuality) {
+		toolOptions = append(toolOptions, huh.NewOption(f.Prompt, f.Name).Selected(f.Enabled(config)))
+	}
 	var selectedTools []string
 	toolingForm := huh.NewForm(
 		huh.NewGroup(
 			huh.NewMultiSelect[string]().
 				Title("Code quality tools").
 				Description("Select the tools you want to include").
-				Options(
-					huh.NewOption("clang-format (code formatting)", "clang-format").Selected(true),
-					huh.NewOption("clang-tidy (static analysis)", "clang-tidy").Selected(true),
-					huh.NewOption("Sanitizers (ASan, UBSan, TSan)", "sanitizers"),
-					huh.NewOption("Code coverage (gcov/lcov)", "coverage"),
-					huh.NewOption("Doxygen (documentation)", "doxygen"),
-					huh.NewOption("pre-commit hooks", "pre-commit"),
-				).
+				Options(toolOptions...).
 				Value(&selectedTools),
 		).Title("Code Quality"),
 	)
@@ -223,60 +336,40 @@ func RunPrompts() (*Config, error) {
 	if err := toolingForm.Run(); err != nil {
 		return nil, err
 	}
-
-	// Parse selected tools
-	for _, tool := range selectedTools {
-		switch tool {
-		case "clang-format":
-			config.UseClangFormat = true
-		case "clang-tidy":
-			config.UseClangTidy = true
-		case "sanitizers":
-			config.UseSanitizers = true
-		case "coverage":
-			config.UseCoverage = true
-		case "doxygen":
-			config.UseDoxygen = true
-		case "pre-commit":
-			config.UsePreCommit = true
-		}
+	for _, f := range promptedFeatures(config, GroupQuality) {
+		f.Set(config, slices.Contains(selectedTools, f.Name))
 	}
 
 	// Page 4: DevOps & IDE
-	devopsForm := huh.NewForm(
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("License").
-				Options(
-					huh.NewOption("MIT", "mit"),
-					huh.NewOption("Apache 2.0", "apache2"),
-					huh.NewOption("GPL 3.0", "gpl3"),
-					huh.NewOption("BSD 3-Clause", "bsd3"),
-					huh.NewOption("None", "none"),
-				).
-				Value(&config.License),
-
-			huh.NewConfirm().
-				Title("Include GitHub Actions CI?").
-				Description("Automated builds, tests, and linting").
-				Value(&config.IncludeCI),
-
-			huh.NewConfirm().
-				Title("Include VSCode configuration?").
-				Description("Settings, launch configs, and recommended extensions").
-				Value(&config.IncludeVSCode),
+	devopsFields := []huh.Field{
+		huh.NewSelect[string]().
+			Title("License").
+			Options(
+				huh.NewOption("MIT", "mit"),
+				huh.NewOption("Apache 2.0", "apache2"),
+				huh.NewOption("GPL 3.0", "gpl3"),
+				huh.NewOption("BSD 3-Clause", "bsd3"),
+				huh.NewOption("None", "none"),
+			).
+			Value(&config.License),
+	}
+	devopsFields = append(devopsFields, featureConfirms(config, GroupDevOps)...)
 
-			huh.NewConfirm().
-				Title("Include Docker support?").
-				Description("Dockerfile and devcontainer for VS Code").
-				Value(&config.UseDocker),
-		).Title("DevOps & IDE"),
+	devopsForm := huh.NewForm(
+		huh.NewGroup(devopsFields...).Title("DevOps & IDE"),
 	)
 
 	if err := devopsForm.Run(); err != nil {
 		return nil, err
 	}
 
+	// Features the project cannot use stay off
+	for i := range Features {
+		if f := &Features[i]; f.Supported != nil && !f.Supported(config) {
+			f.Set(config, false)
+		}
+	}
+
 	// Set defaults
 	if config.ProjectName == "" {
 		config.ProjectName = defaultName
@@ -293,28 +386,210 @@ func RunPrompts() (*Config, error) {
 	}
 	config.OutputDir = config.ProjectName
 
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
 	return config, nil
 }
 
+// promptedFeatures returns the features of group the wizard asks about for
+// config
+func promptedFeatures(config *Config, group string) []*Feature {
+	var features []*Feature
+	for i := range Features {
+		f := &Features[i]
+		if f.Group == group && f.Field != nil && (f.Supported == nil || f.Supported(config)) {
+			features = append(features, f)
+		}
+	}
+	return features
+}
+
+// featureConfirms asks yes or no for each feature of group
+func featureConfirms(config *Config, group string) []huh.Field {
+	var fields []huh.Field
+	for _, f := range promptedFeatures(config, group) {
+		fields = append(fields, huh.NewConfirm().
+			Title(f.Prompt).
+			Description(f.Hint).
+			Value(f.Field(config)))
+	}
+	return fields
+}
+
+// PromptConflict asks whether an existing file should be overwritten,
+// optionally showing a diff against the planned content first
+func PromptConflict(filename, existing, planned string) (bool, error) {
+	for {
+		var choice string
+		form := huh.NewForm(
+			huh.NewGroup(
+				huh.NewSelect[string]().
+					Title(filename+" already exists").
+					Description("What should happen to the existing file?").
+					Options(
+						huh.NewOption("Skip (keep existing file)", "skip"),
+						huh.NewOption("Overwrite", "overwrite"),
+						huh.NewOption("Show diff", "diff"),
+					).
+					Value(&choice),
+			),
+		)
+
+		if err := form.Run(); err != nil {
+			return false, err
+		}
+
+		switch choice {
+		case "overwrite":
+			return true, nil
+		case "skip":
+			return false, nil
+		default:
+			fmt.Println()
+			fmt.Print(UnifiedDiff(filename+" (existing)", filename+" (generated)", existing, planned))
+			fmt.Println()
+		}
+	}
+}
+
+// PromptPackOptions asks for the options of a template pack that were not
+// already given in values and returns all of them resolved
+func PromptPackOptions(pack *Pack, values map[string]any) (map[string]any, error) {
+	resolved, err := pack.ResolveOptions(values)
+	if err != nil {
+		return nil, err
+	}
+
+	strs := make(map[string]*string)
+	bools := make(map[string]*bool)
+	var fields []huh.Field
+	for _, o := range pack.Options {
+		if _, given := values[o.Name]; given {
+			continue
+		}
+		title := o.Prompt
+		if title == "" {
+			title = o.Name
+		}
+
+		switch o.Type {
+		case PackOptionBool:
+			v := resolved[o.Name].(bool)
+			bools[o.Name] = &v
+			fields = append(fields, huh.NewConfirm().
+				Title(title).
+				Description(o.Description).
+				Value(&v))
+		case PackOptionSelect:
+			v := resolved[o.Name].(string)
+			strs[o.Name] = &v
+			fields = append(fields, huh.NewSelect[string]().
+				Title(title).
+				Description(o.Description).
+				Options(huh.NewOptions(o.Choices...)...).
+				Value(&v))
+		default:
+			v := resolved[o.Name].(string)
+			strs[o.Name] = &v
+			fields = append(fields, huh.NewInput().
+				Title(title).
+				Description(o.Description).
+				Value(&v))
+		}
+	}
+	if len(fields) == 0 {
+		return resolved, nil
+	}
+
+	form := huh.NewForm(
+		huh.NewGroup(fields...).Title(pack.Name + " Options"),
+	)
+	if err := form.Run(); err != nil {
+		return nil, err
+	}
+
+	for name, v := range strs {
+		resolved[name] = *v
+	}
+	for name, v := range bools {
+		resolved[name] = *v
+	}
+	return resolved, nil
+}
+
 func validateProjectName(s string) error {
 	if s == "" {
 		return nil // Will use placeholder
 	}
+	return validateName("project name", s)
+}
+
+// validateName checks a name that becomes a single directory
+func validateName(what, s string) error {
 	if strings.ContainsAny(s, " /\\:*?\"<>|") {
-		return fmt.Errorf("project name cannot contain special characters")
+		return fmt.Errorf("%s cannot contain special characters", what)
 	}
 	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "-") {
-		return fmt.Errorf("project name cannot start with . or -")
+		return fmt.Errorf("%s cannot start with . or -", what)
 	}
 	return nil
 }
 
+// NextStep is a suggested command to run after creating a project
+type NextStep struct {
+	Description string   `json:"description"`
+	Commands    []string `json:"commands"`
+}
+
+// enterStep describes the cd into the project directory
+const enterStep = "Enter the project directory"
+
+// NextSteps returns the commands to build and use a new project
+func NextSteps(config *Config) []NextStep {
+	var steps []NextStep
+	if config.OutputDir != "." {
+		steps = append(steps, NextStep{enterStep, []string{"cd " + templates.ShellQuote(config.OutputDir)}})
+	}
+	steps = append(steps, NextStep{"Configure and build", []string{"cmake --preset debug", "cmake --build --preset debug"}})
+	for _, f := range ActiveFeatures(config) {
+		if f.NextSteps != nil {
+			steps = append(steps, f.NextSteps(config)...)
+		}
+	}
+	return steps
+}
+
 // PrintSuccess prints the success message with next steps
-func PrintSuccess(config *Config) {
+func PrintSuccess(config *Config, result *Result) {
 	fmt.Println()
 	fmt.Println(successStyle.Render("✓ Project created successfully!"))
 	fmt.Println()
 
+	if len(result.Skipped) > 0 {
+		fmt.Println("Kept existing files:")
+		for _, filename := range result.Skipped {
+			fmt.Printf("  • %s\n", filename)
+		}
+		fmt.Println()
+	}
+
+	if config.HasExistingCode() {
+		fmt.Println("Using existing code:")
+		switch len(config.Sources) {
+		case 0:
+		case 1:
+			fmt.Println("  • 1 source file in src/")
+		default:
+			fmt.Printf("  • %d source files in src/\n", len(config.Sources))
+		}
+		if config.OwnHeaders {
+			fmt.Println("  • headers in include/")
+		}
+		fmt.Println()
+	}
+
 	// Show what was created
 	fmt.Println("Created project with:")
 	if config.IsC() {
@@ -322,62 +597,27 @@ func PrintSuccess(config *Config) {
 	} else {
 		fmt.Printf("  • C++%s %s\n", config.Standard, config.ProjectType)
 	}
-	if config.TestFramework != "none" {
-		fmt.Printf("  • %s testing\n", config.TestFramework)
-	}
 	if config.PackageManager != "none" {
 		fmt.Printf("  • %s package manager\n", config.PackageManager)
 	}
-	if config.UseClangFormat {
-		fmt.Println("  • clang-format")
-	}
-	if config.UseClangTidy {
-		fmt.Println("  • clang-tidy")
-	}
-	if config.UseSanitizers {
-		fmt.Println("  • Address/UB/Thread sanitizers")
-	}
-	if config.UseCoverage {
-		fmt.Println("  • Code coverage")
-	}
-	if config.IncludeCI {
-		fmt.Println("  • GitHub Actions CI")
-	}
-	if config.UseDocker {
-		fmt.Println("  • Docker & devcontainer")
+	for _, f := range ActiveFeatures(config) {
+		if f.Summary != nil {
+			fmt.Printf("  • %s\n", f.Summary(config))
+		}
 	}
 
 	fmt.Println()
 	fmt.Println("Next steps:")
 	fmt.Println()
-	fmt.Printf("  %s\n", pathStyle.Render(fmt.Sprintf("cd %s", config.ProjectName)))
-	fmt.Println()
-	fmt.Println("  # Configure and build")
-	fmt.Println("  cmake --preset debug")
-	fmt.Println("  cmake --build --preset debug")
-	fmt.Println()
-
-	if config.TestFramework != "none" {
-		fmt.Println("  # Run tests")
-		fmt.Println("  ctest --preset debug")
-		fmt.Println()
-	}
-
-	if config.UseSanitizers {
-		fmt.Println("  # Run with sanitizers")
-		fmt.Println("  cmake --preset asan && cmake --build --preset asan")
-		fmt.Println()
-	}
-
-	if config.UsePreCommit {
-		fmt.Println("  # Setup pre-commit hooks")
-		fmt.Println("  pip install pre-commit && pre-commit install")
-		fmt.Println()
-	}
-
-	if config.UseDocker {
-		fmt.Println("  # Or use Docker")
-		fmt.Println("  docker build -t", config.ProjectName, ".")
+	for _, step := range NextSteps(config) {
+		if step.Description == enterStep {
+			fmt.Printf("  %s\n", pathStyle.Render(step.Commands[0]))
+		} else {
+			fmt.Printf("  # %s\n", step.Description)
+			for _, command := range step.Commands {
+				fmt.Printf("  %s\n", command)
+			}
+		}
 		fmt.Println()
 	}
 
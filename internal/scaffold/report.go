@@ -0,0 +1,112 @@
+package scaffold
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+)
+
+// Report is the machine-readable result of one command, printed by -json
+// instead of the human output. Fields that do not apply to a command, or
+// are empty, are left out.
+type Report struct {
+	Command     string `json:"command"`
+	OK          bool   `json:"ok"`
+	Version     string `json:"version"`                // cppinit version
+	GeneratedBy string `json:"generated_by,omitempty"` // cppinit version recorded in a project
+	DryRun      bool   `json:"dry_run,omitempty"`
+
+	OutputDir string       `json:"output_dir,omitempty"`
+	Archive   string       `json:"archive,omitempty"`
+	Config    *Config      `json:"config,omitempty"`
+	Files     []FileReport `json:"files,omitempty"`   // written, or planned for a dry run
+	Skipped   []string     `json:"skipped,omitempty"` // existing files that were kept
+
+	Changes []FileChange  `json:"changes,omitempty"` // add, remove and upgrade
+	Status  []FileStatus  `json:"status,omitempty"`  // info
+	Checks  []CheckResult `json:"checks,omitempty"`  // doctor
+
+	Options  map[string][]string `json:"options,omitempty"`
+	Features []FeatureReport     `json:"features,omitempty"`
+	Presets  []*Preset           `json:"presets,omitempty"`
+	Packs    []*Pack             `json:"packs,omitempty"`
+	Defaults []Default           `json:"defaults,omitempty"` // config
+
+	NextSteps []NextStep   `json:"next_steps,omitempty"`
+	Warnings  []string     `json:"warnings"`
+	Error     *ErrorReport `json:"error,omitempty"`
+}
+
+// FileReport is one file of a report
+type FileReport struct {
+	Path string `json:"path"`
+	Size int    `json:"size"`
+}
+
+// FeatureReport is one feature of add and remove
+type FeatureReport struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Error codes of an ErrorReport
+const (
+	CodeInvalidOptions   = "invalid_options"   // Problems lists every invalid option
+	CodeFilesExist       = "files_exist"       // Paths lists the files in the way
+	CodeNotFound         = "not_found"         // a file, directory or project is missing
+	CodePermissionDenied = "permission_denied" // the filesystem refused access
+	CodeMissingTools     = "missing_tools"     // doctor found required tools missing
+	CodeFailed           = "failed"            // any other error
+)
+
+// ErrorReport describes the error a command failed with
+type ErrorReport struct {
+	Code     string    `json:"code"`
+	Message  string    `json:"message"`
+	Problems []Problem `json:"problems,omitempty"`
+	Paths    []string  `json:"paths,omitempty"`
+}
+
+// NewReport returns an empty report for command
+func NewReport(command, version string) *Report {
+	return &Report{Command: command, Version: version, Warnings: []string{}}
+}
+
+// SetPlan reports every file of the plan with its size
+func (r *Report) SetPlan(plan *Plan) {
+	r.Files = nil
+	for _, filename := range plan.Paths() {
+		r.Files = append(r.Files, FileReport{Path: filename, Size: len(plan.Files[filename])})
+	}
+}
+
+// SetError reports err with the code of its type
+func (r *Report) SetError(err error) {
+	r.OK = false
+	e := &ErrorReport{Code: CodeFailed, Message: err.Error()}
+
+	var invalid *ValidationError
+	var conflict *ConflictError
+	var missing *MissingToolsError
+	switch {
+	case errors.As(err, &invalid):
+		e.Code, e.Problems = CodeInvalidOptions, invalid.Problems
+	case errors.As(err, &conflict):
+		e.Code, e.Paths = CodeFilesExist, conflict.Paths
+	case errors.As(err, &missing):
+		e.Code = CodeMissingTools
+	case errors.Is(err, fs.ErrNotExist):
+		e.Code = CodeNotFound
+	case errors.Is(err, fs.ErrPermission):
+		e.Code = CodePermissionDenied
+	}
+	r.Error = e
+}
+
+// Write prints the report as indented JSON
+func (r *Report) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
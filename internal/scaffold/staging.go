@@ -0,0 +1,120 @@
+package scaffold
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// WritePlan writes a plan below outputDir all-or-nothing. Every file is
+// first written to a staging directory next to outputDir and only moved
+// into place once all of them were written; the staging directory is
+// removed on any error.
+func WritePlan(outputDir string, plan *Plan) error {
+	outputDir = filepath.Clean(outputDir)
+	parent := filepath.Dir(outputDir)
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", parent, err)
+	}
+
+	staging, err := os.MkdirTemp(parent, ".cppinit-staging-*")
+	if err != nil {
+		return fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	defer os.RemoveAll(staging)
+
+	if err := os.Chmod(staging, 0755); err != nil {
+		return fmt.Errorf("failed to prepare staging directory: %w", err)
+	}
+
+	if err := writeFiles(staging, plan); err != nil {
+		return err
+	}
+
+	// A fresh project is moved into place in a single rename
+	if _, err := os.Lstat(outputDir); errors.Is(err, fs.ErrNotExist) {
+		if err := os.Rename(staging, outputDir); err != nil {
+			return fmt.Errorf("failed to move project into place: %w", err)
+		}
+		return nil
+	}
+
+	return commitInto(staging, outputDir, plan)
+}
+
+// commitInto moves staged files into an existing directory one by one. Files
+// that get replaced are backed up first so that a failure part way through
+// restores the directory to its previous state.
+func commitInto(staging, outputDir string, plan *Plan) (err error) {
+	backup := filepath.Join(staging, ".cppinit-backup")
+
+	var placed, backedUp, createdDirs []string
+	defer func() {
+		if err == nil {
+			return
+		}
+		for i := len(placed) - 1; i >= 0; i-- {
+			os.Remove(filepath.Join(outputDir, placed[i]))
+		}
+		for _, filename := range backedUp {
+			os.Rename(filepath.Join(backup, filename), filepath.Join(outputDir, filename))
+		}
+		for i := len(createdDirs) - 1; i >= 0; i-- {
+			os.Remove(createdDirs[i]) // only succeeds while empty
+		}
+	}()
+
+	// mkdirAll creates dir and records every level it had to create
+	mkdirAll := func(dir string) error {
+		var missing []string
+		for d := dir; ; d = filepath.Dir(d) {
+			if _, statErr := os.Stat(d); statErr == nil {
+				break
+			}
+			missing = append(missing, d)
+			if d == outputDir || d == filepath.Dir(d) {
+				break
+			}
+		}
+		for i := len(missing) - 1; i >= 0; i-- {
+			if err := os.Mkdir(missing[i], 0755); err != nil && !errors.Is(err, fs.ErrExist) {
+				return err
+			}
+			createdDirs = append(createdDirs, missing[i])
+		}
+		return nil
+	}
+
+	for _, dir := range plan.Dirs {
+		if err := mkdirAll(filepath.Join(outputDir, dir)); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+	}
+
+	for _, filename := range plan.Paths() {
+		target := filepath.Join(outputDir, filename)
+		if err := mkdirAll(filepath.Dir(target)); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", filename, err)
+		}
+
+		if _, statErr := os.Lstat(target); statErr == nil {
+			saved := filepath.Join(backup, filename)
+			if err := os.MkdirAll(filepath.Dir(saved), 0755); err != nil {
+				return fmt.Errorf("failed to back up %s: %w", filename, err)
+			}
+			if err := os.Rename(target, saved); err != nil {
+				return fmt.Errorf("failed to back up %s: %w", filename, err)
+			}
+			backedUp = append(backedUp, filename)
+		}
+
+		if err := os.Rename(filepath.Join(staging, filename), target); err != nil {
+			return fmt.Errorf("failed to write %s: %w", filename, err)
+		}
+		placed = append(placed, filename)
+	}
+
+	return nil
+}
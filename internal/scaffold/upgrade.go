@@ -0,0 +1,168 @@
+package scaffold
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/nikitalobanov12/cppinit/internal/templates"
+)
+
+// UpgradeOptions controls Upgrade
+type UpgradeOptions struct {
+	// Version is the cppinit version recorded in the updated manifest
+	Version string
+
+	// DryRun reports the changes without writing anything
+	DryRun bool
+
+	// Reject writes conflicting template changes to <file>.rej instead of
+	// adding conflict markers to the file
+	Reject bool
+	// Templates replace built-in templates; nil renders only the built-in ones
+	Templates *templates.Set
+}
+
+// Upgrade re-renders the project in dir from its recorded config with the
+// current templates and merges the result into the files on disk. For every
+// file it compares the old render recorded in the manifest, the new render
+// and the current content: template changes to untouched files are applied
+// directly, changes on both sides are merged three ways, and the manifest
+// is rewritten to describe the new render. Files whose merge conflicted
+// keep their old record until a later upgrade applies the change.
+func Upgrade(dir string, opts UpgradeOptions) ([]FileChange, error) {
+	m, err := ReadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	bases, err := m.MergeBases()
+	if err != nil {
+		return nil, err
+	}
+	rendered, err := BuildPlan(m.Config, opts.Templates)
+	if err != nil {
+		return nil, err
+	}
+	writes := &Plan{Files: make(map[string]string)}
+	// The files the new manifest records, the new render unless a template
+	// change was not applied
+	recorded := &Plan{Files: maps.Clone(rendered.Files)}
+	var removals []string
+	var changes []FileChange
+
+	paths := rendered.Paths()
+	for filename := range m.Files {
+		if _, ok := rendered.Files[filename]; !ok {
+			paths = append(paths, filename)
+		}
+	}
+	sort.Strings(paths)
+
+	for _, filename := range paths {
+		record, tracked := m.Files[filename]
+		theirs, generated := rendered.Files[filename]
+
+		data, err := os.ReadFile(filepath.Join(dir, filename))
+		exists := err == nil
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("failed to read %s: %w", filename, err)
+		}
+		current := string(data)
+		untouched := exists && tracked && hashContent(data) == record.SHA256
+
+		change := FileChange{Path: filename}
+		switch {
+		case !generated:
+			// The templates no longer produce this file
+			if !exists {
+				continue
+			}
+			if !untouched {
+				change.Action, change.Reason = ChangeKept, "no longer generated, but modified locally"
+				break
+			}
+			removals = append(removals, filename)
+			change.Action = ChangeRemoved
+
+		case tracked && hashContent([]byte(theirs)) == record.SHA256:
+			// Template unchanged, whatever the user did stays
+			continue
+
+		case !exists && tracked:
+			change.Action, change.Reason = ChangeKept, "deleted locally"
+
+		case !exists || untouched:
+			writes.Files[filename] = theirs
+			change.Action = ChangeUpdated
+			if !tracked {
+				change.Action = ChangeCreated
+			}
+
+		case current == theirs:
+			continue
+
+		default:
+			// Changed on both sides. Without a recorded base (a new file that
+			// already exists) the whole file is one conflicting region.
+			base := bases[filename]
+
+			merged, conflicts := Merge3(base, current, theirs)
+			switch {
+			case conflicts == 0:
+				writes.Files[filename] = merged
+				change.Action = ChangeMerged
+			case opts.Reject:
+				writes.Files[filename+".rej"] = UnifiedDiff(filename+" (generated)", filename+" (cppinit "+opts.Version+")", base, theirs)
+				change.Action, change.Conflicts = ChangeRejected, conflicts
+			default:
+				writes.Files[filename] = merged
+				change.Action, change.Conflicts = ChangeConflict, conflicts
+			}
+			if conflicts > 0 {
+				// Until the conflict is resolved the old render stays the
+				// base, so the next upgrade offers the change again
+				if _, ok := bases[filename]; ok {
+					recorded.Files[filename] = base
+				} else {
+					delete(recorded.Files, filename)
+				}
+			}
+		}
+		changes = append(changes, change)
+	}
+
+	if opts.DryRun {
+		return changes, nil
+	}
+
+	// The new render becomes the base for the next upgrade
+	writes.Files[ManifestFile] = NewManifest(m.Config, opts.Version, recorded).Render()
+
+	if err := WritePlan(dir, writes); err != nil {
+		return nil, err
+	}
+	for _, filename := range removals {
+		if err := os.Remove(filepath.Join(dir, filename)); err != nil {
+			return nil, fmt.Errorf("failed to remove %s: %w", filename, err)
+		}
+	}
+
+	return changes, nil
+}
+
+// PrintUpgrade prints the outcome of an upgrade
+func PrintUpgrade(changes []FileChange, dryRun bool) {
+	if len(changes) == 0 {
+		fmt.Println(successStyle.Render("✓ Project is up to date with the current templates"))
+		return
+	}
+
+	if printChanges(changes, dryRun) == 0 && !dryRun {
+		fmt.Println(successStyle.Render("✓ Project upgraded"))
+	}
+}
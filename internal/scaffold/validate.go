@@ -0,0 +1,189 @@
+package scaffold
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Valid values for the enum options
+var (
+	Languages         = []string{"c", "c++"}
+	CStandards        = []string{"89", "99", "11", "17", "23"}
+	CppStandards      = []string{"11", "14", "17", "20", "23"}
+	ProjectTypes      = []string{"executable", "static", "header-only"}
+	CTestFrameworks   = []string{"none", "unity"}
+	CppTestFrameworks = []string{"none", "googletest", "catch2", "doctest"}
+	PackageManagers   = []string{"none", "vcpkg", "conan", "cpm"}
+	Licenses          = []string{"none", "mit", "apache2", "gpl3", "bsd3"}
+)
+
+// Problem is a single invalid option or incompatible combination
+type Problem struct {
+	Field      string `json:"field"` // config key of the offending option, e.g. "tests"
+	Message    string `json:"message"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+func (p Problem) String() string {
+	if p.Suggestion == "" {
+		return fmt.Sprintf("%s: %s", p.Field, p.Message)
+	}
+	return fmt.Sprintf("%s: %s (%s)", p.Field, p.Message, p.Suggestion)
+}
+
+// ValidationError lists every problem found by Validate
+type ValidationError struct {
+	Problems []Problem
+}
+
+func (e *ValidationError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("invalid project options:")
+	for _, p := range e.Problems {
+		sb.WriteString("\n  - " + p.String())
+	}
+	return sb.String()
+}
+
+// Validate checks every option and every combination of options and
+// returns a *ValidationError listing all problems at once
+func (c *Config) Validate() error {
+	var problems []Problem
+	add := func(field, suggestion, format string, args ...any) {
+		problems = append(problems, Problem{Field: field, Message: fmt.Sprintf(format, args...), Suggestion: suggestion})
+	}
+
+	if c.ProjectName == "" {
+		add("name", "e.g. cppinit new <name>", "project name is required")
+	} else if err := validateProjectName(c.ProjectName); err != nil {
+		add("name", "", "%v", err)
+	}
+	for _, n := range []struct {
+		field, value string
+		check        func(string) error
+	}{
+		{"identifier", c.Identifier, validateIdentifier},
+		{"macro_prefix", c.MacroPrefix, validateMacroPrefix},
+		{"target", c.Target, validateTarget},
+		{"file_stem", c.FileStem, validateFileStem},
+	} {
+		if err := n.check(n.value); err != nil {
+			add(n.field, "leave it empty to derive it from the project name", "%v", err)
+		}
+	}
+
+	// Enums that do not depend on anything else
+	if !slices.Contains(Languages, c.Language) {
+		add("language", suggest(c.Language, Languages), "unknown language %q", c.Language)
+	}
+	if !slices.Contains(ProjectTypes, c.ProjectType) {
+		add("type", suggest(c.ProjectType, ProjectTypes), "unknown project type %q", c.ProjectType)
+	}
+	if !slices.Contains(PackageManagers, c.PackageManager) {
+		add("package_manager", suggest(c.PackageManager, PackageManagers), "unknown package manager %q", c.PackageManager)
+	}
+	if !slices.Contains(Licenses, c.License) {
+		add("license", suggest(c.License, Licenses), "unknown license %q", c.License)
+	}
+
+	// Language dependent options
+	standards, frameworks, langLabel := CppStandards, CppTestFrameworks, "C++"
+	otherFrameworks := CTestFrameworks
+	if c.IsC() {
+		standards, frameworks, langLabel = CStandards, CTestFrameworks, "C"
+		otherFrameworks = CppTestFrameworks
+	}
+
+	if !slices.Contains(standards, c.Standard) {
+		if slices.Contains(CStandards, c.Standard) || slices.Contains(CppStandards, c.Standard) {
+			add("standard", "use one of "+strings.Join(standards, ", "), "%s%s does not exist", langLabel, c.Standard)
+		} else {
+			add("standard", suggest(c.Standard, standards), "unknown %s standard %q", langLabel, c.Standard)
+		}
+	}
+
+	if !slices.Contains(frameworks, c.TestFramework) {
+		if slices.Contains(otherFrameworks, c.TestFramework) {
+			add("tests", "use one of "+strings.Join(frameworks, ", ")+" or change the language",
+				"%s cannot be used with %s projects", c.TestFramework, langLabel)
+		} else {
+			add("tests", suggest(c.TestFramework, frameworks), "unknown test framework %q", c.TestFramework)
+		}
+	}
+
+	if c.IsC() && c.ProjectType == "header-only" {
+		add("type", "use executable or static, or switch to C++",
+			"header-only libraries are only supported for C++ projects")
+	}
+
+	// Benchmarks link against a C++ library target
+	if c.IncludeBenchmark {
+		if c.IsC() {
+			add("benchmark", "drop -benchmark or switch to C++", "Google Benchmark requires a C++ project")
+		}
+		if c.ProjectType == "executable" {
+			add("benchmark", "use -type static or -type header-only, or drop -benchmark",
+				"benchmarks need a library target to measure")
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// ValidateOutputDir checks the directory a new project is written to, such
+// as libs/net/mynet. Parent directories may be nested or absolute; the last
+// element must be a valid directory name, or . or .. for an existing one.
+func ValidateOutputDir(dir string) error {
+	if dir == "" {
+		return fmt.Errorf("output directory cannot be empty")
+	}
+	base := filepath.Base(filepath.Clean(dir))
+	if base == "." || base == ".." {
+		return nil
+	}
+	if err := validateName("output directory name", base); err != nil {
+		return fmt.Errorf("invalid output directory %s: %w", dir, err)
+	}
+	return nil
+}
+
+// suggest returns a hint naming the closest valid value and listing all of them
+func suggest(value string, valid []string) string {
+	best, bestDist := "", 3 // only suggest reasonably close matches
+	for _, v := range valid {
+		if d := levenshtein(strings.ToLower(value), v); d < bestDist {
+			best, bestDist = v, d
+		}
+	}
+	options := "valid values: " + strings.Join(valid, ", ")
+	if best != "" {
+		return fmt.Sprintf("did you mean %q? %s", best, options)
+	}
+	return options
+}
+
+// levenshtein returns the edit distance between two strings
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,282 @@
+// Package server is the HTTP front end of cppinit serve: a form and a JSON
+// API that generate projects in memory and return them as zip archives.
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/nikitalobanov12/cppinit/internal/scaffold"
+	"github.com/nikitalobanov12/cppinit/pkg/cppinit"
+)
+
+// MaxRequestBytes caps the body of generate requests
+const MaxRequestBytes = 64 << 10
+
+// Server generates projects over HTTP without touching disk
+type Server struct {
+	generator *cppinit.Generator
+	logger    *log.Logger
+}
+
+// New returns a server that generates with g and logs to logger
+func New(g *cppinit.Generator, logger *log.Logger) *Server {
+	return &Server{generator: g, logger: logger}
+}
+
+// Handler returns the routes of the server
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", s.handleForm)
+	mux.HandleFunc("GET /api/options", s.handleOptions)
+	mux.HandleFunc("POST /api/generate", s.handleGenerate)
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		fmt.Fprintln(w, "ok")
+	})
+	return mux
+}
+
+// HTTPServer returns an http.Server for addr with timeouts suited to
+// running behind a proxy
+func (s *Server) HTTPServer(addr string) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       10 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		IdleTimeout:       60 * time.Second,
+		MaxHeaderBytes:    16 << 10,
+	}
+}
+
+// optionJSON is how /api/options describes one option
+type optionJSON struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Type        string   `json:"type"`
+	Choices     []string `json:"choices,omitempty"`
+	Default     string   `json:"default,omitempty"`
+}
+
+func (s *Server) handleOptions(w http.ResponseWriter, r *http.Request) {
+	var options []optionJSON
+	for _, o := range s.generator.Options() {
+		options = append(options, optionJSON{
+			Name:        o.Name,
+			Description: o.Description,
+			Type:        string(o.Type),
+			Choices:     o.Choices,
+			Default:     o.Default,
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"options": options})
+}
+
+// errorJSON is the body of failed API requests
+type errorJSON struct {
+	Error    string             `json:"error"`
+	Problems []scaffold.Problem `json:"problems,omitempty"`
+}
+
+func (s *Server) handleGenerate(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, MaxRequestBytes)
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	isJSON := mediaType == "application/json"
+
+	values, err := readValues(r, isJSON)
+	if err != nil {
+		status := http.StatusBadRequest
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		s.fail(w, r, isJSON, status, err, values)
+		return
+	}
+
+	config, err := s.generator.Configure(values)
+	if err != nil {
+		s.fail(w, r, isJSON, http.StatusBadRequest, err, values)
+		return
+	}
+
+	var buf bytes.Buffer
+	zip := cppinit.NewZipFS(&buf, config.ProjectName)
+	if err := s.generator.Write(config, zip); err != nil {
+		s.fail(w, r, isJSON, http.StatusInternalServerError, err, values)
+		return
+	}
+	if err := zip.Close(); err != nil {
+		s.fail(w, r, isJSON, http.StatusInternalServerError, err, values)
+		return
+	}
+
+	s.logger.Printf("generated %s (%d bytes) for %s", config.ProjectName, buf.Len(), r.RemoteAddr)
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": config.ProjectName + ".zip"}))
+	w.Header().Set("Content-Length", fmt.Sprint(buf.Len()))
+	w.Write(buf.Bytes())
+}
+
+// readValues reads option values from a JSON object or a form. JSON values
+// may be strings, booleans or numbers. In forms the last value of a key wins,
+// so a hidden "false" before a checkbox reports unchecked boxes.
+func readValues(r *http.Request, isJSON bool) (map[string]string, error) {
+	values := make(map[string]string)
+	if isJSON {
+		var body map[string]any
+		dec := json.NewDecoder(r.Body)
+		dec.UseNumber()
+		if err := dec.Decode(&body); err != nil {
+			return nil, fmt.Errorf("failed to parse request: %w", err)
+		}
+		for key, value := range body {
+			switch v := value.(type) {
+			case string, bool, json.Number:
+				values[key] = fmt.Sprint(v)
+			default:
+				return nil, fmt.Errorf("option %s must be a string, boolean or number", key)
+			}
+		}
+		return values, nil
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return nil, fmt.Errorf("failed to parse form: %w", err)
+	}
+	for key, vs := range r.PostForm {
+		if v := vs[len(vs)-1]; v != "" {
+			values[key] = v
+		}
+	}
+	return values, nil
+}
+
+// fail reports an error as JSON to API clients, or by showing the form
+// again with the problems listed
+func (s *Server) fail(w http.ResponseWriter, r *http.Request, isJSON bool, status int, err error, values map[string]string) {
+	if status >= http.StatusInternalServerError {
+		s.logger.Printf("generate failed for %s: %v", r.RemoteAddr, err)
+	}
+
+	body := errorJSON{Error: err.Error()}
+	var invalid *scaffold.ValidationError
+	if errors.As(err, &invalid) {
+		body.Error = "invalid project options"
+		body.Problems = invalid.Problems
+	}
+	if isJSON {
+		writeJSON(w, status, body)
+		return
+	}
+	s.renderForm(w, status, values, body)
+}
+
+// writeJSON encodes v as the response body
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
+
+func (s *Server) handleForm(w http.ResponseWriter, r *http.Request) {
+	s.renderForm(w, http.StatusOK, nil, errorJSON{})
+}
+
+// formField is one option as shown in the form
+type formField struct {
+	cppinit.Option
+	Value string
+}
+
+// renderForm shows the form with values filled in, falling back to defaults
+func (s *Server) renderForm(w http.ResponseWriter, status int, values map[string]string, failure errorJSON) {
+	var fields []formField
+	for _, o := range s.generator.Options() {
+		value, ok := values[o.Name]
+		if !ok {
+			value = o.Default
+		}
+		fields = append(fields, formField{Option: o, Value: value})
+	}
+
+	var buf bytes.Buffer
+	if err := formTemplate.Execute(&buf, map[string]any{"Fields": fields, "Failure": failure}); err != nil {
+		s.logger.Printf("failed to render form: %v", err)
+		http.Error(w, "failed to render form", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(buf.Bytes())
+}
+
+var formTemplate = template.Must(template.New("form").Funcs(template.FuncMap{
+	"title": func(name string) string {
+		return strings.ToUpper(name[:1]) + strings.ReplaceAll(name[1:], "_", " ")
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>cppinit</title>
+<style>
+  body { font-family: system-ui, sans-serif; max-width: 40rem; margin: 2rem auto; padding: 0 1rem; color: #222; }
+  h1 { color: #d7008f; }
+  label { display: block; margin: .8rem 0 .2rem; font-weight: 600; }
+  label.check { font-weight: normal; margin: .4rem 0; }
+  input[type=text], select { width: 100%; padding: .4rem; box-sizing: border-box; }
+  small { color: #666; }
+  .problems { background: #fde8f2; border-left: 4px solid #d7008f; padding: .5rem 1rem; }
+  button { margin-top: 1.5rem; padding: .6rem 1.4rem; font-size: 1rem; }
+</style>
+</head>
+<body>
+<h1>cppinit</h1>
+<p>Generate a modern CMake C or C++ project as a zip archive.</p>
+{{- with .Failure.Error }}
+<div class="problems">
+  <strong>{{ . }}</strong>
+  {{- with $.Failure.Problems }}
+  <ul>{{ range . }}<li>{{ .String }}</li>{{ end }}</ul>
+  {{- end }}
+</div>
+{{- end }}
+<form method="post" action="/api/generate">
+{{- range .Fields }}
+  {{- if eq .Type "bool" }}
+  <input type="hidden" name="{{ .Name }}" value="false">
+  <label class="check"><input type="checkbox" name="{{ .Name }}" value="true"{{ if eq .Value "true" }} checked{{ end }}> {{ .Description }}</label>
+  {{- else if eq .Type "select" }}
+  <label for="{{ .Name }}">{{ title .Name }}</label>
+  <select id="{{ .Name }}" name="{{ .Name }}">
+    {{- if eq .Default "" }}<option value="">default</option>{{ end }}
+    {{- $value := .Value }}
+    {{- range .Choices }}
+    <option{{ if eq . $value }} selected{{ end }}>{{ . }}</option>
+    {{- end }}
+  </select>
+  <small>{{ .Description }}</small>
+  {{- else }}
+  <label for="{{ .Name }}">{{ title .Name }}</label>
+  <input type="text" id="{{ .Name }}" name="{{ .Name }}" value="{{ .Value }}"{{ if eq .Name "name" }} required{{ end }}>
+  <small>{{ .Description }}</small>
+  {{- end }}
+{{- end }}
+  <button type="submit">Generate project</button>
+</form>
+</body>
+</html>
+`))
@@ -0,0 +1,123 @@
+package templates
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Escaping helpers for user-supplied strings such as the project
+// description or author. Each returns text that is safe between the double
+// quotes of its format; markdown returns text that renders literally. The
+// built-in YAML files interpolate only GitHub expressions and validated
+// option values, so yamlString is there for custom templates and packs.
+
+// cmakeString escapes s for a quoted CMake argument, where backslashes,
+// quotes and ${...} variable references are special
+func cmakeString(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		`$`, `\$`,
+		"\n", `\n`,
+		"\r", `\r`,
+		"\t", `\t`,
+	).Replace(s)
+}
+
+// jsonString escapes s for a JSON string
+func jsonString(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s) // strings always encode
+	quoted := strings.TrimSuffix(buf.String(), "\n")
+	return quoted[1 : len(quoted)-1]
+}
+
+// yamlString escapes s for a double-quoted YAML scalar, whose escapes are a
+// superset of JSON's
+func yamlString(s string) string {
+	return jsonString(s)
+}
+
+// cString escapes s for a C or C++ string literal. Control characters
+// become octal escapes, and ? is escaped after another ? so that no
+// trigraph forms.
+func cString(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch b := s[i]; {
+		case b == '\\' || b == '"':
+			sb.WriteByte('\\')
+			sb.WriteByte(b)
+		case b == '\n':
+			sb.WriteString(`\n`)
+		case b == '\t':
+			sb.WriteString(`\t`)
+		case b == '?' && i > 0 && s[i-1] == '?':
+			sb.WriteString(`\?`)
+		case b < 0x20 || b == 0x7f:
+			fmt.Fprintf(&sb, `\%03o`, b)
+		default:
+			sb.WriteByte(b)
+		}
+	}
+	return sb.String()
+}
+
+// markdown escapes the characters of s that Markdown would treat as
+// formatting, links or HTML, and block markers at the start of a line:
+// headings, quotes, rules and list markers such as - and 1.
+func markdown(s string) string {
+	var sb strings.Builder
+	for i, line := range strings.Split(s, "\n") {
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+		trimmed := strings.TrimLeft(line, " ")
+		sb.WriteString(line[:len(line)-len(trimmed)])
+		digits := len(trimmed) - len(strings.TrimLeft(trimmed, "0123456789"))
+		switch {
+		case trimmed != "" && strings.ContainsRune("#-+=", rune(trimmed[0])):
+			sb.WriteByte('\\')
+		case digits > 0 && digits < len(trimmed) && (trimmed[digits] == '.' || trimmed[digits] == ')'):
+			sb.WriteString(trimmed[:digits])
+			sb.WriteByte('\\')
+			trimmed = trimmed[digits:]
+		}
+		for _, r := range trimmed {
+			if strings.ContainsRune("\\`*_[]<>|", r) {
+				sb.WriteByte('\\')
+			}
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// urlPath escapes s for one segment of a URL path
+func urlPath(s string) string {
+	return url.PathEscape(s)
+}
+
+// ShellQuote quotes s for a POSIX shell command line if it needs quoting
+func ShellQuote(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t\n'\"$`\\&;|<>()*?[]#~!{}") {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// singleLine replaces the line breaks and other control characters of s
+// with spaces, for plain text such as the copyright line of a license
+func singleLine(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return ' '
+		}
+		return r
+	}, s)
+}
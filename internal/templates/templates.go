@@ -0,0 +1,141 @@
+// Package templates holds the built-in project templates and renders them,
+// letting files in override directories replace individual templates.
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// Ext is the extension of template files
+const Ext = ".tmpl"
+
+//go:embed all:files
+var embedded embed.FS
+
+// builtin is the embedded template tree rooted at files/
+var builtin, _ = fs.Sub(embedded, "files")
+
+// Set renders templates by name, e.g. "cmake/CompilerWarnings.cmake.tmpl".
+// Override directories are searched in order before the built-in templates.
+// In an override directory a template can be replaced either by a file of
+// the same name, which is rendered, or by the name without the .tmpl
+// extension, which is copied verbatim.
+type Set struct {
+	dirs []string
+}
+
+// New returns a set that searches dirs before the built-in templates.
+// Empty entries are ignored.
+func New(dirs ...string) *Set {
+	s := &Set{}
+	for _, dir := range dirs {
+		if dir != "" {
+			s.dirs = append(s.dirs, dir)
+		}
+	}
+	return s
+}
+
+// WithFallback returns a copy of the set that also searches dir, after the
+// existing override directories but before the built-in templates
+func (s *Set) WithFallback(dir string) *Set {
+	dirs := append([]string{}, s.Dirs()...)
+	return New(append(dirs, dir)...)
+}
+
+// Dirs returns the override directories in search order
+func (s *Set) Dirs() []string {
+	if s == nil {
+		return nil
+	}
+	return s.dirs
+}
+
+// UserDir returns the per-user override directory,
+// ~/.config/cppinit/templates on Linux, or "" if it cannot be determined
+func UserDir() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "cppinit", "templates")
+}
+
+// source finds the text of a template and whether it should be rendered
+func (s *Set) source(name string) (text string, origin string, render bool, err error) {
+	for _, dir := range s.Dirs() {
+		for _, candidate := range []string{name, strings.TrimSuffix(name, Ext)} {
+			path := filepath.Join(dir, filepath.FromSlash(candidate))
+			data, err := os.ReadFile(path)
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return "", "", false, fmt.Errorf("failed to read template override %s: %w", path, err)
+			}
+			return string(data), path, candidate == name, nil
+		}
+	}
+
+	data, err := fs.ReadFile(builtin, name)
+	if err != nil {
+		return "", "", false, fmt.Errorf("unknown template %s", name)
+	}
+	return string(data), name, true, nil
+}
+
+// Render renders the named template with data
+func (s *Set) Render(name string, data any) (string, error) {
+	text, origin, render, err := s.source(name)
+	if err != nil {
+		return "", err
+	}
+	if !render {
+		return text, nil
+	}
+
+	return RenderText(origin, text, data)
+}
+
+// RenderText renders template text with the standard helper functions.
+// Parse and execute errors name the template and line.
+func RenderText(name, text string, data any) (string, error) {
+	tmpl, err := template.New(name).Funcs(funcs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// funcs are the helpers available to every template
+var funcs = template.FuncMap{
+	// gh writes a GitHub Actions expression, which uses the same braces as
+	// text/template: {{ gh "matrix.os" }} renders ${{ matrix.os }}
+	"gh": func(expr string) string {
+		return "${{ " + expr + " }}"
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+
+	// Escaping for user-supplied strings, see escape.go
+	"cmakeString": cmakeString,
+	"jsonString":  jsonString,
+	"yamlString":  yamlString,
+	"cString":     cString,
+	"markdown":    markdown,
+	"urlPath":     urlPath,
+	"shellQuote":  ShellQuote,
+	"singleLine":  singleLine,
+}
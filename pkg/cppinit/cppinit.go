@@ -0,0 +1,151 @@
+// Package cppinit is the public API of the cppinit project generator. It
+// plans and writes C and C++ projects for other Go programs, without the
+// command line or its interactive prompts.
+//
+//	g := cppinit.New()
+//	config, err := g.Configure(map[string]string{"name": "demo", "tests": "googletest"})
+//	if err != nil { ... }
+//	err = g.Write(config, cppinit.DirFS("demo"))
+package cppinit
+
+import (
+	"sort"
+
+	"github.com/nikitalobanov12/cppinit/internal/scaffold"
+	"github.com/nikitalobanov12/cppinit/internal/templates"
+)
+
+// Config describes the project to generate; see DefaultConfig
+type Config = scaffold.Config
+
+// ValidationError lists every invalid option of a Config
+type ValidationError = scaffold.ValidationError
+
+// Problem is one entry of a ValidationError
+type Problem = scaffold.Problem
+
+// ManifestFile is the generation record included in every project, which
+// the CLI's info, upgrade, add and remove commands read
+const ManifestFile = scaffold.ManifestFile
+
+// DefaultConfig returns the options the CLI uses when no flags are given.
+// Only the project name has to be filled in.
+func DefaultConfig() *Config {
+	return scaffold.DefaultConfig()
+}
+
+// Validate checks every option and every combination of options and returns
+// a *ValidationError listing all problems at once. Options derived from the
+// language, like the standard, may be left empty. config is not modified.
+func Validate(config *Config) error {
+	_, err := resolve(config)
+	return err
+}
+
+// resolve returns a validated copy of config with the derived options
+// filled in
+func resolve(config *Config) (*Config, error) {
+	c := *config
+	c.ApplyLanguageDefaults()
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Project is the planned content of a project
+type Project struct {
+	// Dirs lists directories to create, including ones that stay empty
+	Dirs []string
+	// Files maps slash-separated paths relative to the project root to
+	// their content
+	Files map[string]string
+}
+
+// Paths returns the planned file paths in sorted order
+func (p *Project) Paths() []string {
+	paths := make([]string, 0, len(p.Files))
+	for path := range p.Files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Plan renders the project for config with the built-in templates
+func Plan(config *Config) (*Project, error) {
+	return New().Plan(config)
+}
+
+// Write plans the project for config with the built-in templates and writes
+// it to fsys
+func Write(config *Config, fsys FS) error {
+	return New().Write(config, fsys)
+}
+
+// Generator plans and writes projects. Its zero value is not usable; create
+// one with New.
+type Generator struct {
+	// TemplateDirs hold templates that replace built-in ones by name,
+	// searched in order
+	TemplateDirs []string
+
+	// Version is the generator version recorded in ManifestFile
+	Version string
+
+	options  []Option
+	handlers []func(Event)
+}
+
+// New returns a generator that knows the built-in options
+func New() *Generator {
+	return &Generator{options: builtinOptions()}
+}
+
+// Plan renders the project for config without writing anything
+func (g *Generator) Plan(config *Config) (*Project, error) {
+	c, err := resolve(config)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := scaffold.BuildPlan(c, templates.New(g.TemplateDirs...))
+	if err != nil {
+		return nil, err
+	}
+	project := &Project{Dirs: plan.Dirs, Files: plan.Files}
+	g.emit(Event{Kind: EventPlanned, Project: project})
+
+	// The record hashes the files as the handlers left them
+	final := &scaffold.Plan{Dirs: project.Dirs, Files: project.Files}
+	if final.Files == nil {
+		final.Files = map[string]string{}
+	}
+	final.AddManifest(c, g.Version)
+	project.Files = final.Files
+	return project, nil
+}
+
+// Write plans the project for config and writes it to fsys. Handlers of
+// EventPlanned see the project before anything is written.
+func (g *Generator) Write(config *Config, fsys FS) error {
+	project, err := g.Plan(config)
+	if err != nil {
+		return err
+	}
+
+	for _, dir := range project.Dirs {
+		if err := fsys.MkdirAll(dir); err != nil {
+			return err
+		}
+	}
+	for _, path := range project.Paths() {
+		content := project.Files[path]
+		if err := fsys.WriteFile(path, []byte(content)); err != nil {
+			return err
+		}
+		g.emit(Event{Kind: EventFileWritten, Path: path, Size: len(content)})
+	}
+
+	g.emit(Event{Kind: EventDone, Project: project})
+	return nil
+}
@@ -0,0 +1,36 @@
+package cppinit
+
+// EventKind identifies a stage of generation
+type EventKind string
+
+const (
+	EventPlanned     EventKind = "planned" // project rendered, nothing written yet
+	EventFileWritten EventKind = "file"    // one file written
+	EventDone        EventKind = "done"    // every file written
+)
+
+// Event reports progress to the handlers registered with OnEvent
+type Event struct {
+	Kind EventKind
+
+	// Path and Size describe the file for EventFileWritten
+	Path string
+	Size int
+
+	// Project is set for EventPlanned and EventDone. Handlers of
+	// EventPlanned may add, change or remove files before they are written.
+	Project *Project
+}
+
+// OnEvent registers a handler that is called synchronously for every event,
+// in registration order
+func (g *Generator) OnEvent(handler func(Event)) {
+	g.handlers = append(g.handlers, handler)
+}
+
+// emit calls every handler with e
+func (g *Generator) emit(e Event) {
+	for _, handler := range g.handlers {
+		handler(e)
+	}
+}
@@ -0,0 +1,43 @@
+package cppinit
+
+import (
+	"io"
+
+	"github.com/nikitalobanov12/cppinit/internal/scaffold"
+)
+
+// FS is where Write puts a project. Paths are slash-separated and relative
+// to the project root.
+type FS = scaffold.FS
+
+// MemFS keeps a written project in memory
+type MemFS = scaffold.MemFS
+
+// TarGzFS streams a project into a .tar.gz; call Close to finish it
+type TarGzFS = scaffold.TarGzFS
+
+// ZipFS streams a project into a .zip; call Close to finish it
+type ZipFS = scaffold.ZipFS
+
+// DirFS returns an FS that writes below root on disk, creating it as
+// needed. Existing files are overwritten; check Plan first if that matters.
+func DirFS(root string) FS {
+	return scaffold.DirFS(root)
+}
+
+// NewMemFS returns an empty in-memory FS
+func NewMemFS() *MemFS {
+	return scaffold.NewMemFS()
+}
+
+// NewTarGzFS returns an FS that writes a gzip-compressed tar archive to w,
+// with every entry below root (e.g. the project name; may be empty)
+func NewTarGzFS(w io.Writer, root string) *TarGzFS {
+	return scaffold.NewTarGzFS(w, root)
+}
+
+// NewZipFS returns an FS that writes a zip archive to w, with every entry
+// below root (e.g. the project name; may be empty)
+func NewZipFS(w io.Writer, root string) *ZipFS {
+	return scaffold.NewZipFS(w, root)
+}
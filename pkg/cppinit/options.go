@@ -0,0 +1,190 @@
+package cppinit
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nikitalobanov12/cppinit/internal/scaffold"
+)
+
+// OptionType tells front ends which input to show for an option
+type OptionType string
+
+const (
+	OptionString OptionType = "string"
+	OptionBool   OptionType = "bool"
+	OptionSelect OptionType = "select"
+)
+
+// Option is a named project option that can be set from text, e.g. from a
+// web form. The built-in options use the keys of cppinit config files.
+type Option struct {
+	Name        string
+	Description string
+	Type        OptionType
+	Choices     []string // valid values of a select option
+	Default     string   // shown to users; DefaultConfig already holds it
+
+	// Apply stores a value in the config. Bool values arrive as "true" or
+	// "false" and select values are already checked against Choices.
+	Apply func(c *Config, value string) error
+}
+
+// RegisterOption adds an option, e.g. one that fills in PackOptions or
+// derives several fields from a company-specific choice. Options are applied
+// in registration order after the built-in ones.
+func (g *Generator) RegisterOption(o Option) error {
+	if o.Name == "" || o.Apply == nil {
+		return fmt.Errorf("option needs a name and an Apply function")
+	}
+	if g.option(o.Name) != nil {
+		return fmt.Errorf("option %s is already registered", o.Name)
+	}
+	if o.Type == "" {
+		o.Type = OptionString
+	}
+	if o.Type == OptionSelect && len(o.Choices) == 0 {
+		return fmt.Errorf("select option %s has no choices", o.Name)
+	}
+	g.options = append(g.options, o)
+	return nil
+}
+
+// Options returns the built-in and registered options in the order they are
+// applied
+func (g *Generator) Options() []Option {
+	return slices.Clone(g.options)
+}
+
+// option finds a registered option by name
+func (g *Generator) option(name string) *Option {
+	for i := range g.options {
+		if g.options[i].Name == name {
+			return &g.options[i]
+		}
+	}
+	return nil
+}
+
+// Configure builds a config from option values on top of DefaultConfig. The
+// standard, description and output directory follow from the other options
+// when not given. The result is validated.
+func (g *Generator) Configure(values map[string]string) (*Config, error) {
+	c := DefaultConfig()
+	c.Standard = "" // derived from the language
+
+	var problems []Problem
+	names := make([]string, 0, len(g.options))
+	for _, o := range g.options {
+		names = append(names, o.Name)
+		value, ok := values[o.Name]
+		if !ok {
+			continue
+		}
+		if err := o.set(c, value); err != nil {
+			problems = append(problems, Problem{Field: o.Name, Message: err.Error()})
+		}
+	}
+	for name := range values {
+		if g.option(name) == nil {
+			problems = append(problems, Problem{
+				Field:      name,
+				Message:    "unknown option",
+				Suggestion: "valid options: " + strings.Join(names, ", "),
+			})
+		}
+	}
+	if len(problems) > 0 {
+		sort.Slice(problems, func(i, j int) bool { return problems[i].Field < problems[j].Field })
+		return nil, &ValidationError{Problems: problems}
+	}
+
+	return resolve(c)
+}
+
+// set checks a value against the option's type and applies it
+func (o *Option) set(c *Config, value string) error {
+	switch o.Type {
+	case OptionBool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%q is not true or false", value)
+		}
+		value = strconv.FormatBool(b)
+	case OptionSelect:
+		if !slices.Contains(o.Choices, value) {
+			return fmt.Errorf("unknown value %q (valid values: %s)", value, strings.Join(o.Choices, ", "))
+		}
+	}
+	return o.Apply(c, value)
+}
+
+// builtinOptions describes every Config field as an option
+func builtinOptions() []Option {
+	text := func(name, description string, field func(*Config) *string) Option {
+		return Option{
+			Name:        name,
+			Description: description,
+			Type:        OptionString,
+			Apply: func(c *Config, value string) error {
+				*field(c) = value
+				return nil
+			},
+		}
+	}
+	choice := func(name, description, def string, choices []string, field func(*Config) *string) Option {
+		o := text(name, description, field)
+		o.Type = OptionSelect
+		o.Choices = choices
+		o.Default = def
+		return o
+	}
+	flag := func(name, description string, def bool, field func(*Config) *bool) Option {
+		return Option{
+			Name:        name,
+			Description: description,
+			Type:        OptionBool,
+			Default:     strconv.FormatBool(def),
+			Apply: func(c *Config, value string) error {
+				*field(c) = value == "true"
+				return nil
+			},
+		}
+	}
+
+	standards := append([]string{"89", "99"}, scaffold.CppStandards...)
+	tests := append(slices.Clone(scaffold.CppTestFrameworks), "unity")
+
+	options := []Option{
+		text("name", "Project name", func(c *Config) *string { return &c.ProjectName }),
+		text("description", "Project description", func(c *Config) *string { return &c.Description }),
+		text("author", "Author name for the license", func(c *Config) *string { return &c.AuthorName }),
+		text("email", "Author email", func(c *Config) *string { return &c.AuthorEmail }),
+		text("repo", "Repository URL", func(c *Config) *string { return &c.GitRepo }),
+		text("identifier", "C/C++ identifier (default: derived from the name)", func(c *Config) *string { return &c.Identifier }),
+		text("macro_prefix", "Include guard and macro prefix (default: derived from the identifier)",
+			func(c *Config) *string { return &c.MacroPrefix }),
+		text("target", "CMake target name (default: derived from the name)", func(c *Config) *string { return &c.Target }),
+		text("file_stem", "Header and source file name (default: derived from the name)",
+			func(c *Config) *string { return &c.FileStem }),
+		choice("language", "Language", "c++", scaffold.Languages, func(c *Config) *string { return &c.Language }),
+		choice("standard", "Language standard (C: 89, 99, 11, 17, 23; C++: 11, 14, 17, 20, 23)", "",
+			standards, func(c *Config) *string { return &c.Standard }),
+		choice("type", "Project type", "executable", scaffold.ProjectTypes, func(c *Config) *string { return &c.ProjectType }),
+		choice("tests", "Test framework (unity for C, the others for C++)", "none", tests,
+			func(c *Config) *string { return &c.TestFramework }),
+		choice("package_manager", "Package manager", "none", scaffold.PackageManagers,
+			func(c *Config) *string { return &c.PackageManager }),
+		choice("license", "License", "mit", scaffold.Licenses, func(c *Config) *string { return &c.License }),
+	}
+	defaults := scaffold.DefaultConfig()
+	for _, f := range scaffold.Features {
+		if f.Field != nil {
+			options = append(options, flag(f.Key(), f.Description, *f.Field(defaults), f.Field))
+		}
+	}
+	return options
+}